@@ -108,6 +108,23 @@ describe("CluesContainer Component", () => {
       ).toBeTruthy();
     });
 
+    it("should reveal the curated clues in order when the item has them", () => {
+      const clues = ["A man returns home.", "Zorvath hunts the wizard Quellin."];
+      setMockStoreState({
+        playerGame: {
+          triviaItem: { ...defaultTriviaItem, description: mockSummary, clues },
+        },
+      });
+      renderWithTheme(<CluesContainer />);
+
+      act(() => {
+        jest.runAllTimers();
+      });
+
+      expect(screen.getByText(clues[0])).toBeTruthy();
+      expect(screen.getByText("4/9 words revealed")).toBeTruthy();
+    });
+
     it("should render the full summary if the game is already over on load", () => {
       setMockStoreState({
         isInteractionsDisabled: true,
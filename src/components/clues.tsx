@@ -86,6 +86,7 @@ const CluesContainer = memo(() => {
     correctAnswer,
     guesses,
     itemDescription,
+    itemClues,
     isInteractionsDisabled,
     difficulty,
     loading,
@@ -94,15 +95,20 @@ const CluesContainer = memo(() => {
       correctAnswer: state.playerGame.correctAnswer,
       guesses: state.playerGame.guesses,
       itemDescription: state.playerGame.triviaItem?.description,
+      itemClues: state.playerGame.triviaItem?.clues,
       isInteractionsDisabled: state.isInteractionsDisabled,
       difficulty: state.difficulty,
       loading: state.loading,
     }))
   )
 
+  // Prefer the clues curated by the data pipeline, vaguest first.
   const clues = useMemo(
-    () => splitSummary(itemDescription || ""),
-    [itemDescription]
+    () =>
+      itemClues && itemClues.length > 0
+        ? itemClues
+        : splitSummary(itemDescription || ""),
+    [itemClues, itemDescription]
   )
   const [revealedClues, setRevealedClues] = useState<string[]>([])
   const [typewriterText, setTypewriterText] = useState("")
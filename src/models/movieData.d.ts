@@ -0,0 +1,73 @@
+// Code generated by talkie types; DO NOT EDIT.
+
+/** A movie document in the Firestore 'movies' collection (popularMovies.json). */
+export interface RawMovie {
+  actors: MovieActor[]
+  director: MovieDirector
+  genres: Genre[]
+  id: number
+  imdb_id: string
+  keywords?: string[]
+  certification?: string
+  collection?: Collection | null
+  original_overview: string
+  overview: string
+  overviews?: Record<string, string>
+  manual_overview?: string
+  clues?: string[]
+  popularity: number
+  poster_path: string
+  release_date: string
+  tagline: string
+  original_tagline?: string
+  tagline_unsafe?: boolean
+  title: string
+  alternative_titles?: string[]
+  vote_average: number
+  vote_count: number
+}
+
+/** An entry in basicMovies.json, the answer picker index. */
+export interface JsonBasicMovie {
+  id: number
+  title: string
+  release_date: string
+  poster_path: string
+}
+
+/** An entry in moviesLite.json, the bundled hints: director, genres, cast and year. */
+export interface LiteMovie {
+  id: number
+  d: string
+  g: string[]
+  c: string[]
+  y: string
+}
+
+export interface MovieActor {
+  id: number
+  order: number
+  name: string
+  character?: string
+  popularity: number
+  profile_path: string
+  imdb_id?: string
+}
+
+export interface MovieDirector {
+  id: number
+  name: string
+  popularity: number
+  profile_path: string
+  imdb_id?: string
+}
+
+export interface Genre {
+  id: number
+  name: string
+}
+
+export interface Collection {
+  id: number
+  name: string
+}
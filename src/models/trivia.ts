@@ -26,6 +26,7 @@ export interface TriviaItem {
   id: number | string
   title: string
   description: string // The main clue text (e.g., plot summary, gameplay description)
+  clues?: string[] // Optional: description pre-split into clues to reveal in order
   posterPath: string
   releaseDate: string
 
@@ -3,43 +3,11 @@ import { GameMode, TriviaItem, BasicTriviaItem } from "../models/trivia"
 import { db } from "./firebaseClient"
 import { doc, getDoc } from "firebase/firestore"
 import { FIRESTORE_COLLECTIONS } from "../config/constants"
+import type { JsonBasicMovie, LiteMovie, RawMovie } from "../models/movieData"
 
 import basicMoviesData from "../../data/basicMovies.json"
 import moviesLiteData from "../../data/moviesLite.json"
 
-interface JsonBasicMovie {
-  id: number
-  title: string
-  release_date: string
-  poster_path: string
-}
-
-interface LiteMovie {
-  id: number
-  d: string // Director
-  g: string[] | null // Genres
-  c: string[] | null // Cast
-  y: string // Year
-}
-
-interface RawMovie {
-  id: number
-  title: string
-  overview: string
-  manual_overview?: string
-
-  poster_path: string
-  release_date: string
-  tagline: string
-  imdb_id: string
-  popularity: number
-  vote_average: number
-  vote_count: number
-  genres: { id: number; name: string }[]
-  director: { id: number; name: string; imdb_id?: string }
-  actors: { id: number; name: string; imdb_id?: string; order: number }[]
-}
-
 export class MovieDataService implements IGameDataService {
   public mode: GameMode = "movies"
 
@@ -69,6 +37,7 @@ export class MovieDataService implements IGameDataService {
       id: movie.id,
       title: movie.title,
       description: finalDescription,
+      clues: movie.clues,
       posterPath: movie.poster_path || "",
       releaseDate: movie.release_date || "",
       metadata: {
@@ -1,3 +0,0 @@
-module basicMovies
-
-go 1.23.1
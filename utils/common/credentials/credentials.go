@@ -0,0 +1,96 @@
+// Package credentials resolves TMDB and Google Cloud credentials. Each is
+// taken from the first source that provides it: command-line flags,
+// environment variables, the optional secrets file and, for Google Cloud
+// only, Application Default Credentials.
+package credentials
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+)
+
+// Environment variables consulted after flags.
+const (
+	EnvTMDBKey           = "TMDB_API_KEY"
+	EnvTMDBToken         = "TMDB_ACCESS_TOKEN"
+	EnvServiceAccountKey = "TALKIE_SERVICE_ACCOUNT_KEY"
+)
+
+// Source says where a credential was found.
+type Source string
+
+const (
+	SourceFlag Source = "flag"
+	SourceEnv  Source = "environment"
+	SourceFile Source = "secrets file"
+	SourceADC  Source = "application default credentials"
+)
+
+// Secrets is the optional secrets file. ServiceAccountKey is the path of a
+// service account key file, relative to the secrets file.
+type Secrets struct {
+	TMDBKey           string `json:"TMDBKey,omitempty"`
+	TMDBToken         string `json:"TMDBToken,omitempty"`
+	ServiceAccountKey string `json:"ServiceAccountKey,omitempty"`
+
+	dir string
+}
+
+// LoadSecrets reads the secrets file at path. A missing file yields empty
+// secrets, since every credential can also come from elsewhere.
+func LoadSecrets(path string) (Secrets, error) {
+	var secrets Secrets
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return secrets, nil
+	}
+	if err != nil {
+		return secrets, fmt.Errorf("could not read secrets file: %w", err)
+	}
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return secrets, fmt.Errorf("could not parse secrets file %s: %w", path, err)
+	}
+	secrets.dir = filepath.Dir(path)
+	return secrets, nil
+}
+
+// TMDB returns the TMDB credentials from flags, the environment or secrets,
+// whichever provides an API key or access token first.
+func TMDB(flags tmdb.Credentials, secrets Secrets) (tmdb.Credentials, Source, error) {
+	if !flags.Empty() {
+		return flags, SourceFlag, nil
+	}
+	if env := (tmdb.Credentials{APIKey: os.Getenv(EnvTMDBKey), AccessToken: os.Getenv(EnvTMDBToken)}); !env.Empty() {
+		return env, SourceEnv, nil
+	}
+	if file := (tmdb.Credentials{APIKey: secrets.TMDBKey, AccessToken: secrets.TMDBToken}); !file.Empty() {
+		return file, SourceFile, nil
+	}
+	return tmdb.Credentials{}, "", fmt.Errorf("no TMDB credentials: pass -tmdb-key or -tmdb-token, set %s or %s, or add TMDBKey or TMDBToken to the secrets file", EnvTMDBKey, EnvTMDBToken)
+}
+
+// ServiceAccountKey returns the service account key file from flag, the
+// environment or secrets. It returns "" with SourceADC when none is set, in
+// which case Google Cloud clients fall back to Application Default
+// Credentials.
+func ServiceAccountKey(flag string, secrets Secrets) (string, Source) {
+	if flag != "" {
+		return flag, SourceFlag
+	}
+	if env := os.Getenv(EnvServiceAccountKey); env != "" {
+		return env, SourceEnv
+	}
+	if secrets.ServiceAccountKey != "" {
+		path := secrets.ServiceAccountKey
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(secrets.dir, path)
+		}
+		return path, SourceFile
+	}
+	return "", SourceADC
+}
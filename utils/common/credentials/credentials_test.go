@@ -0,0 +1,67 @@
+package credentials
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+)
+
+func TestTMDBPrecedence(t *testing.T) {
+	secrets := Secrets{TMDBKey: "file-key"}
+
+	t.Setenv(EnvTMDBKey, "")
+	t.Setenv(EnvTMDBToken, "")
+	if creds, src, err := TMDB(tmdb.Credentials{}, secrets); err != nil || src != SourceFile || creds.APIKey != "file-key" {
+		t.Errorf("secrets file: got %+v from %q (%v)", creds, src, err)
+	}
+
+	t.Setenv(EnvTMDBToken, "env-token")
+	if creds, src, _ := TMDB(tmdb.Credentials{}, secrets); src != SourceEnv || creds != (tmdb.Credentials{AccessToken: "env-token"}) {
+		t.Errorf("environment: got %+v from %q", creds, src)
+	}
+
+	if creds, src, _ := TMDB(tmdb.Credentials{APIKey: "flag-key"}, secrets); src != SourceFlag || creds.APIKey != "flag-key" {
+		t.Errorf("flag: got %+v from %q", creds, src)
+	}
+
+	t.Setenv(EnvTMDBToken, "")
+	if _, _, err := TMDB(tmdb.Credentials{}, Secrets{}); err == nil {
+		t.Error("expected an error without any credentials")
+	}
+}
+
+func TestServiceAccountKey(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secrets.json")
+	if err := os.WriteFile(path, []byte(`{"ServiceAccountKey": "sa.json"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	secrets, err := LoadSecrets(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvServiceAccountKey, "")
+	if got, src := ServiceAccountKey("", secrets); got != filepath.Join(dir, "sa.json") || src != SourceFile {
+		t.Errorf("secrets file: got %q from %q", got, src)
+	}
+	if got, src := ServiceAccountKey("", Secrets{}); got != "" || src != SourceADC {
+		t.Errorf("fallback: got %q from %q", got, src)
+	}
+	t.Setenv(EnvServiceAccountKey, "/env/sa.json")
+	if got, src := ServiceAccountKey("", secrets); got != "/env/sa.json" || src != SourceEnv {
+		t.Errorf("environment: got %q from %q", got, src)
+	}
+	if got, src := ServiceAccountKey("/flag/sa.json", secrets); got != "/flag/sa.json" || src != SourceFlag {
+		t.Errorf("flag: got %q from %q", got, src)
+	}
+}
+
+func TestLoadSecretsMissingFile(t *testing.T) {
+	secrets, err := LoadSecrets(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil || secrets != (Secrets{}) {
+		t.Errorf("LoadSecrets(missing) = %+v, %v", secrets, err)
+	}
+}
@@ -0,0 +1,171 @@
+// Package filters holds the quality gates that decide which TMDB movies are
+// usable as trivia candidates. The thresholds live in a shared JSON config
+// (utils/filters.json) with one named profile per use case, so every tool
+// applies the same rules.
+package filters
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// FileName is the config file's name in the utils directory.
+const FileName = "filters.json"
+
+// Rule names, used as keys in rejection reports.
+const (
+	RuleOverviewTooShort = "overview_length_gt"
+	RuleOverviewTooLong  = "overview_length_lt"
+	RuleRuntime          = "runtime_gt"
+	RulePopularity       = "popularity_gt"
+	RuleVoteAverage      = "vote_average_gt"
+	RuleVoteCount        = "vote_count_gt"
+)
+
+// Profile is one named set of thresholds. Every bound is exclusive and an
+// omitted bound disables its rule.
+type Profile struct {
+	OverviewLengthGT *int     `json:"overview_length_gt,omitempty"`
+	OverviewLengthLT *int     `json:"overview_length_lt,omitempty"`
+	RuntimeGT        *int     `json:"runtime_gt,omitempty"`
+	PopularityGT     *float64 `json:"popularity_gt,omitempty"`
+	VoteAverageGT    *float64 `json:"vote_average_gt,omitempty"`
+	VoteCountGT      *int     `json:"vote_count_gt,omitempty"`
+}
+
+// Config is the on-disk filter configuration.
+type Config struct {
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// Load reads a filter config from path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read filter config %s: %w", path, err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not parse filter config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Gate returns a gate for the named profile.
+func (c *Config) Gate(name string) (*Gate, error) {
+	p, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("filter profile %q not found", name)
+	}
+	return &Gate{Name: name, Profile: p, rejected: make(map[string]int)}, nil
+}
+
+// Candidate carries the fields the rules look at.
+type Candidate struct {
+	Overview    string
+	Runtime     int
+	Popularity  float64
+	VoteAverage float64
+	VoteCount   int
+}
+
+// Failures returns the names of every rule c fails under p.
+func (p Profile) Failures(c Candidate) []string {
+	var failed []string
+	if p.OverviewLengthGT != nil && len(c.Overview) <= *p.OverviewLengthGT {
+		failed = append(failed, RuleOverviewTooShort)
+	}
+	if p.OverviewLengthLT != nil && len(c.Overview) >= *p.OverviewLengthLT {
+		failed = append(failed, RuleOverviewTooLong)
+	}
+	if p.RuntimeGT != nil && c.Runtime <= *p.RuntimeGT {
+		failed = append(failed, RuleRuntime)
+	}
+	if p.PopularityGT != nil && c.Popularity <= *p.PopularityGT {
+		failed = append(failed, RulePopularity)
+	}
+	if p.VoteAverageGT != nil && c.VoteAverage <= *p.VoteAverageGT {
+		failed = append(failed, RuleVoteAverage)
+	}
+	if p.VoteCountGT != nil && c.VoteCount <= *p.VoteCountGT {
+		failed = append(failed, RuleVoteCount)
+	}
+	return failed
+}
+
+// Gate applies a profile and counts how many candidates each rule rejected.
+// It is safe for concurrent use.
+type Gate struct {
+	Name    string
+	Profile Profile
+
+	mu       sync.Mutex
+	checked  int
+	accepted int
+	rejected map[string]int
+}
+
+// Allow reports whether c passes every rule, recording the outcome.
+func (g *Gate) Allow(c Candidate) bool {
+	return g.AllowKnown(c)
+}
+
+// AllowKnown is like Allow but skips the named rules, for candidates whose
+// fields those rules look at are not known, such as movies re-checked from a
+// stored record that has no runtime.
+func (g *Gate) AllowKnown(c Candidate, skip ...string) bool {
+	var failed []string
+	for _, rule := range g.Profile.Failures(c) {
+		if !contains(skip, rule) {
+			failed = append(failed, rule)
+		}
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.checked++
+	if len(failed) == 0 {
+		g.accepted++
+		return true
+	}
+	for _, rule := range failed {
+		g.rejected[rule]++
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Report returns a summary line followed by one line per rule that rejected
+// at least one candidate, most rejections first. A candidate failing several
+// rules is counted under each of them.
+func (g *Gate) Report() []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	lines := []string{fmt.Sprintf("Filter profile %q: %d checked, %d accepted, %d rejected", g.Name, g.checked, g.accepted, g.checked-g.accepted)}
+	rules := make([]string, 0, len(g.rejected))
+	for rule := range g.rejected {
+		rules = append(rules, rule)
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		if g.rejected[rules[i]] != g.rejected[rules[j]] {
+			return g.rejected[rules[i]] > g.rejected[rules[j]]
+		}
+		return rules[i] < rules[j]
+	})
+	for _, rule := range rules {
+		lines = append(lines, fmt.Sprintf("  %-20s rejected %d", rule, g.rejected[rule]))
+	}
+	return lines
+}
@@ -0,0 +1,95 @@
+package filters
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSharedConfigProfiles(t *testing.T) {
+	cfg, err := Load(filepath.Join("..", "..", "filters.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"daily", "practice", "picker"} {
+		if _, err := cfg.Gate(name); err != nil {
+			t.Errorf("profile %q: %v", name, err)
+		}
+	}
+	if _, err := cfg.Gate("missing"); err == nil {
+		t.Error("expected an error for an unknown profile")
+	}
+}
+
+func TestFailures(t *testing.T) {
+	sixty, fourHundred, seventyFive, fourHundredVotes := 60, 400, 75, 400
+	ten, avg := 10.0, 4.9
+	daily := Profile{
+		OverviewLengthGT: &sixty,
+		OverviewLengthLT: &fourHundred,
+		RuntimeGT:        &seventyFive,
+		PopularityGT:     &ten,
+		VoteAverageGT:    &avg,
+		VoteCountGT:      &fourHundredVotes,
+	}
+	good := Candidate{Overview: strings.Repeat("x", 100), Runtime: 120, Popularity: 50, VoteAverage: 7, VoteCount: 5000}
+
+	tests := []struct {
+		name   string
+		mutate func(*Candidate)
+		want   []string
+	}{
+		{"passes", func(c *Candidate) {}, nil},
+		{"bounds are exclusive", func(c *Candidate) { c.Runtime = 75 }, []string{RuleRuntime}},
+		{"short overview", func(c *Candidate) { c.Overview = "short" }, []string{RuleOverviewTooShort}},
+		{"long overview", func(c *Candidate) { c.Overview = strings.Repeat("x", 400) }, []string{RuleOverviewTooLong}},
+		{"several rules", func(c *Candidate) { c.Popularity, c.VoteCount = 1, 10 }, []string{RulePopularity, RuleVoteCount}},
+	}
+	for _, tt := range tests {
+		c := good
+		tt.mutate(&c)
+		if got := daily.Failures(c); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Failures = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	if got := (Profile{}).Failures(Candidate{}); got != nil {
+		t.Errorf("empty profile rejected a candidate: %v", got)
+	}
+}
+
+func TestGateReport(t *testing.T) {
+	five := 5
+	g := &Gate{Name: "test", Profile: Profile{RuntimeGT: &five, VoteCountGT: &five}, rejected: map[string]int{}}
+	g.Allow(Candidate{Runtime: 10, VoteCount: 10})
+	g.Allow(Candidate{Runtime: 1, VoteCount: 10})
+	g.Allow(Candidate{Runtime: 1, VoteCount: 1})
+
+	want := []string{
+		`Filter profile "test": 3 checked, 1 accepted, 2 rejected`,
+		"  runtime_gt           rejected 2",
+		"  vote_count_gt        rejected 1",
+	}
+	if got := g.Report(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Report() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestGateAllowKnown(t *testing.T) {
+	five := 5
+	g := &Gate{Name: "test", Profile: Profile{RuntimeGT: &five, VoteCountGT: &five}, rejected: map[string]int{}}
+	if !g.AllowKnown(Candidate{VoteCount: 10}, RuleRuntime) {
+		t.Error("AllowKnown applied a skipped rule")
+	}
+	if g.AllowKnown(Candidate{VoteCount: 1}, RuleRuntime) {
+		t.Error("AllowKnown ignored a rule that was not skipped")
+	}
+	want := []string{
+		`Filter profile "test": 2 checked, 1 accepted, 1 rejected`,
+		"  vote_count_gt        rejected 1",
+	}
+	if got := g.Report(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Report() =\n%q\nwant\n%q", got, want)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/unrealities/talkie-trivia/utils/common
+
+go 1.23.1
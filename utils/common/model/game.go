@@ -0,0 +1,13 @@
+package model
+
+import "time"
+
+// DailyGame represents the structure of a document in the 'dailyGames' collection.
+// Seed and AlgorithmVersion record how the schedule was planned, so the run
+// can be reproduced.
+type DailyGame struct {
+	MovieID          int       `firestore:"movieId"`
+	Date             time.Time `firestore:"date"`
+	Seed             int64     `firestore:"seed,omitempty"`
+	AlgorithmVersion int       `firestore:"algorithmVersion,omitempty"`
+}
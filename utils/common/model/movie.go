@@ -0,0 +1,85 @@
+// Package model defines the movie records produced by the data tools and
+// stored in the app bundle and Firestore. The JSON and Firestore field names
+// are defined here and nowhere else.
+package model
+
+// Movie is a full trivia record, as written to popularMovies.json and the
+// Firestore 'movies' collection. Overview is the sanitized OriginalOverview;
+// Overviews holds graded variants of it by difficulty tier ("easy",
+// "medium" and "hard"), and Clues splits the overview the app shows into
+// segments to reveal one at a time, vaguest first. Tagline is the sanitized
+// OriginalTagline, left empty and flagged TaglineUnsafe when redaction can't
+// make it safe. AltTitles are other English titles the movie is known by,
+// redacted like Title.
+type Movie struct {
+	Actors           []MovieActor      `json:"actors" firestore:"actors"`
+	Director         MovieDirector     `json:"director" firestore:"director"`
+	Genres           []Genre           `json:"genres" firestore:"genres"`
+	ID               int               `json:"id" firestore:"id"`
+	ImdbID           string            `json:"imdb_id" firestore:"imdb_id"`
+	Keywords         []string          `json:"keywords,omitempty" firestore:"keywords,omitempty"`
+	Certification    string            `json:"certification,omitempty" firestore:"certification,omitempty"`
+	Collection       *Collection       `json:"collection,omitempty" firestore:"collection,omitempty"`
+	OriginalOverview string            `json:"original_overview" firestore:"original_overview"`
+	Overview         string            `json:"overview" firestore:"overview"`
+	Overviews        map[string]string `json:"overviews,omitempty" firestore:"overviews,omitempty"`
+	ManualOverview   string            `json:"manual_overview,omitempty" firestore:"manual_overview,omitempty"`
+	Clues            []string          `json:"clues,omitempty" firestore:"clues,omitempty"`
+	Popularity       float64           `json:"popularity" firestore:"popularity"`
+	PosterPath       string            `json:"poster_path" firestore:"poster_path"`
+	ReleaseDate      string            `json:"release_date" firestore:"release_date"`
+	Tagline          string            `json:"tagline" firestore:"tagline"`
+	OriginalTagline  string            `json:"original_tagline,omitempty" firestore:"original_tagline,omitempty"`
+	TaglineUnsafe    bool              `json:"tagline_unsafe,omitempty" firestore:"tagline_unsafe,omitempty"`
+	Title            string            `json:"title" firestore:"title"`
+	AltTitles        []string          `json:"alternative_titles,omitempty" firestore:"alternative_titles,omitempty"`
+	VoteAverage      float64           `json:"vote_average" firestore:"vote_average"`
+	VoteCount        int               `json:"vote_count" firestore:"vote_count"`
+}
+
+// BasicMovie is an entry in the bundled search index, basicMovies.json.
+type BasicMovie struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	ReleaseDate string `json:"release_date"`
+	PosterPath  string `json:"poster_path"`
+}
+
+// LiteMovie is an entry in the bundled hint file, moviesLite.json. Keys are
+// minimized to save space: "d" = Director, "g" = Genres, "c" = Cast, "y" = Year.
+type LiteMovie struct {
+	ID       int      `json:"id"`
+	Director string   `json:"d"`
+	Genres   []string `json:"g"`
+	Cast     []string `json:"c"`
+	Year     string   `json:"y"`
+}
+
+// Collection is the TMDB collection (franchise) a movie belongs to.
+type Collection struct {
+	ID   int    `json:"id" firestore:"id"`
+	Name string `json:"name" firestore:"name"`
+}
+
+type Genre struct {
+	ID   int    `json:"id" firestore:"id"`
+	Name string `json:"name" firestore:"name"`
+}
+
+type MovieActor struct {
+	ID          int     `json:"id" firestore:"id"`
+	Order       int     `json:"order" firestore:"order"`
+	Name        string  `json:"name" firestore:"name"`
+	Character   string  `json:"character,omitempty" firestore:"character,omitempty"`
+	Popularity  float64 `json:"popularity" firestore:"popularity"`
+	ProfilePath string  `json:"profile_path" firestore:"profile_path"`
+	ImdbID      string  `json:"imdb_id,omitempty" firestore:"imdb_id,omitempty"`
+}
+
+type MovieDirector struct {
+	ID          int     `json:"id" firestore:"id"`
+	Name        string  `json:"name" firestore:"name"`
+	Popularity  float64 `json:"popularity" firestore:"popularity"`
+	ProfilePath string  `json:"profile_path" firestore:"profile_path"`
+	ImdbID      string  `json:"imdb_id,omitempty" firestore:"imdb_id,omitempty"`
+}
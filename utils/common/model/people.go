@@ -0,0 +1,26 @@
+package model
+
+// Actor aggregates an actor's appearances across the dataset.
+type Actor struct {
+	ID          int          `json:"id"`
+	MovieCount  int          `json:"movie_count"`
+	MovieOrders []MovieOrder `json:"movie_orders"`
+	Name        string       `json:"name"`
+	Popularity  float64      `json:"popularity"`
+	ProfilePath string       `json:"profile_path"`
+}
+
+// Director aggregates a director's films across the dataset.
+type Director struct {
+	ID          int     `json:"id"`
+	MovieCount  int     `json:"movie_count"`
+	Name        string  `json:"name"`
+	Popularity  float64 `json:"popularity"`
+	ProfilePath string  `json:"profile_path"`
+}
+
+// MovieOrder is an actor's billing position in one movie.
+type MovieOrder struct {
+	ID    int `json:"id"`
+	Order int `json:"order"`
+}
@@ -0,0 +1,116 @@
+package tmdb
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrCacheMiss is returned in offline mode when a response was never cached.
+var ErrCacheMiss = errors.New("response not in cache (offline mode)")
+
+// CacheEntry is one stored TMDB response.
+type CacheEntry struct {
+	Endpoint  string            `json:"endpoint"`
+	Params    map[string]string `json:"params,omitempty"`
+	ETag      string            `json:"etag,omitempty"`
+	FetchedAt time.Time         `json:"fetched_at"`
+	Body      []byte            `json:"body"`
+}
+
+// Cache is a content-addressed on-disk cache of TMDB responses.
+// Entries are keyed by endpoint and query parameters; the API key is never
+// part of the key so rotating it does not invalidate the cache.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+	// Offline serves every entry regardless of age and never touches the
+	// network; misses fail with ErrCacheMiss.
+	Offline bool
+}
+
+// NewCache opens (creating if needed) a cache rooted at dir.
+func NewCache(dir string, ttl time.Duration, offline bool) (*Cache, error) {
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("could not create cache directory %s: %w", dir, err)
+	}
+	return &Cache{Dir: dir, TTL: ttl, Offline: offline}, nil
+}
+
+// CacheKey hashes the endpoint and the sorted parameters, excluding api_key.
+func CacheKey(endpoint string, params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k == "api_key" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString(endpoint)
+	for _, k := range keys {
+		b.WriteString("\x00")
+		b.WriteString(k)
+		b.WriteString("=")
+		b.WriteString(params[k])
+	}
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cache) path(key string) string {
+	// Shard by prefix so no single directory grows to tens of thousands of files.
+	return filepath.Join(c.Dir, key[:2], key+".json")
+}
+
+// Get returns the entry for key, or nil if there is none.
+func (c *Cache) Get(key string) *CacheEntry {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil
+	}
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// Fresh reports whether entry can be served without revalidation.
+func (c *Cache) Fresh(entry *CacheEntry) bool {
+	return c.Offline || time.Since(entry.FetchedAt) < c.TTL
+}
+
+// Put stores entry under key, replacing any previous entry atomically.
+func (c *Cache) Put(key string, entry *CacheEntry) error {
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,77 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCacheKeyIgnoresAPIKey(t *testing.T) {
+	a := CacheKey("/movie/1", map[string]string{"page": "1", "api_key": "one"})
+	b := CacheKey("/movie/1", map[string]string{"api_key": "two", "page": "1"})
+	if a != b {
+		t.Errorf("keys differ by api_key: %s vs %s", a, b)
+	}
+	if c := CacheKey("/movie/1", map[string]string{"page": "2"}); c == a {
+		t.Error("different params produced the same key")
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var calls, notModified atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	cache, err := NewCache(dir, time.Hour, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := testClient(srv.URL)
+	f.Cache = cache
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		body, err := f.Get(ctx, "/movie/1", nil)
+		if err != nil || string(body) != `{"id":1}` {
+			t.Fatalf("get #%d = %q, %v", i, body, err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("fresh entry hit the network: %d calls", got)
+	}
+
+	// An expired entry is revalidated with its ETag.
+	cache.TTL = 0
+	body, err := f.Get(ctx, "/movie/1", nil)
+	if err != nil || string(body) != `{"id":1}` {
+		t.Fatalf("revalidated get = %q, %v", body, err)
+	}
+	if notModified.Load() != 1 {
+		t.Errorf("expected one 304 revalidation, got %d", notModified.Load())
+	}
+
+	// Offline mode serves cached entries regardless of age and never dials.
+	srv.Close()
+	offline, _ := NewCache(dir, 0, true)
+	f.Cache = offline
+	if body, err := f.Get(ctx, "/movie/1", nil); err != nil || string(body) != `{"id":1}` {
+		t.Fatalf("offline get = %q, %v", body, err)
+	}
+	if _, err := f.Get(ctx, "/movie/2", nil); !errors.Is(err, ErrCacheMiss) {
+		t.Errorf("offline miss error = %v, want ErrCacheMiss", err)
+	}
+}
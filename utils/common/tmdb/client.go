@@ -0,0 +1,216 @@
+// Package tmdb is a small client for The Movie Database v3 API with rate
+// limiting, retries and an optional on-disk response cache, plus the response
+// types the data tools decode.
+package tmdb
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BaseURL is the TMDB v3 API root.
+const BaseURL = "https://api.themoviedb.org/3"
+
+// Credentials authenticate TMDB requests, either with a v3 API key sent as
+// the api_key query parameter or with a v4 read access token sent as a
+// bearer token. The token wins when both are set.
+type Credentials struct {
+	APIKey      string
+	AccessToken string
+}
+
+// Empty reports whether neither credential is set.
+func (c Credentials) Empty() bool {
+	return c.APIKey == "" && c.AccessToken == ""
+}
+
+// Client bundles what every TMDB request needs. It is safe for concurrent
+// use once configured.
+type Client struct {
+	HTTP        *http.Client
+	BaseURL     string
+	Credentials Credentials
+	Limiter     *RateLimiter
+	Retry       RetryPolicy
+	Cache       *Cache
+	// Revalidate forces cached entries to be revalidated regardless of age.
+	Revalidate bool
+}
+
+// NewClient returns a client with default timeouts and retry policy and no
+// rate limiting or cache.
+func NewClient(creds Credentials) *Client {
+	return &Client{
+		HTTP:        &http.Client{Timeout: 10 * time.Second},
+		BaseURL:     BaseURL,
+		Credentials: creds,
+		Retry:       DefaultRetryPolicy,
+	}
+}
+
+// GetJSON fetches endpoint and decodes the body into v.
+func (c *Client) GetJSON(ctx context.Context, endpoint string, params map[string]string, v interface{}) error {
+	body, err := c.Get(ctx, endpoint, params)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse %s response: %w", endpoint, err)
+	}
+	return nil
+}
+
+// Get returns the response body for endpoint, serving it from the cache when
+// a fresh entry exists and revalidating stale entries by ETag.
+func (c *Client) Get(ctx context.Context, endpoint string, params map[string]string) ([]byte, error) {
+	if c.Cache == nil {
+		resp, err := c.fetch(ctx, endpoint, params, "")
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
+	}
+
+	key := CacheKey(endpoint, params)
+	cached := c.Cache.Get(key)
+	if cached != nil && !c.Revalidate && c.Cache.Fresh(cached) {
+		return cached.Body, nil
+	}
+	if c.Cache.Offline {
+		return nil, fmt.Errorf("%s: %w", endpoint, ErrCacheMiss)
+	}
+
+	etag := ""
+	if cached != nil {
+		etag = cached.ETag
+	}
+	resp, err := c.fetch(ctx, endpoint, params, etag)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &CacheEntry{
+		Endpoint:  endpoint,
+		Params:    params,
+		ETag:      resp.ETag,
+		FetchedAt: time.Now().UTC(),
+		Body:      resp.Body,
+	}
+	if resp.NotModified {
+		entry.Body = cached.Body
+		if entry.ETag == "" {
+			entry.ETag = cached.ETag
+		}
+	}
+	if err := c.Cache.Put(key, entry); err != nil {
+		slog.Warn("Could not cache response", "endpoint", endpoint, "err", err)
+	}
+	return entry.Body, nil
+}
+
+// fetch performs a rate-limited request, retrying transient failures according
+// to the retry policy until it succeeds, fails permanently or the per-request
+// deadline passes.
+func (c *Client) fetch(ctx context.Context, endpoint string, params map[string]string, etag string) (*apiResponse, error) {
+	if c.Retry.Deadline > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Retry.Deadline)
+		defer cancel()
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.Limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		resp, err := fetchFromAPI(ctx, c.HTTP, c.BaseURL+endpoint, c.Credentials, params, etag)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("%w (after %d attempts)", err, attempt+1)
+		}
+
+		delay, ok := c.Retry.backoff(attempt, err)
+		// Waiting out the deadline only to fail would stall the worker.
+		if deadline, set := ctx.Deadline(); set && time.Until(deadline) <= delay {
+			ok = false
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w (after %d attempts)", err, attempt+1)
+		}
+		slog.Info("Retrying", "endpoint", endpoint, "in", delay.Round(time.Millisecond), "attempt", attempt+2, "err", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("%w (after %d attempts)", err, attempt+1)
+		case <-timer.C:
+		}
+	}
+}
+
+// apiResponse is a successful TMDB response. NotModified is set, with an
+// empty Body, when a conditional request matched the supplied ETag.
+type apiResponse struct {
+	Body        []byte
+	ETag        string
+	NotModified bool
+}
+
+func fetchFromAPI(ctx context.Context, client *http.Client, rawURL string, creds Credentials, params map[string]string, etag string) (*apiResponse, error) {
+	baseURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	q := baseURL.Query()
+	if creds.AccessToken == "" {
+		q.Set("api_key", creds.APIKey)
+	}
+	for key, val := range params {
+		q.Set(key, val)
+	}
+	baseURL.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if creds.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+creds.AccessToken)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && etag != "":
+		return &apiResponse{ETag: resp.Header.Get("ETag"), NotModified: true}, nil
+	case resp.StatusCode != http.StatusOK:
+		io.Copy(io.Discard, resp.Body)
+		return nil, &APIError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return &apiResponse{Body: body, ETag: resp.Header.Get("ETag")}, nil
+}
@@ -0,0 +1,216 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testClient(serverURL string) *Client {
+	return &Client{
+		HTTP:        &http.Client{Timeout: time.Second},
+		BaseURL:     serverURL,
+		Credentials: Credentials{APIKey: "test-key"},
+		Retry: RetryPolicy{
+			MaxAttempts: 4,
+			BaseDelay:   time.Millisecond,
+			MaxDelay:    5 * time.Millisecond,
+			Deadline:    2 * time.Second,
+		},
+	}
+}
+
+func TestClientRetries(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		retryAfter   string
+		wantErr      bool
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{name: "success", statuses: []int{200}, wantAttempts: 1},
+		{name: "429 then success", statuses: []int{429, 200}, retryAfter: "0", wantAttempts: 2},
+		{name: "5xx then success", statuses: []int{502, 503, 200}, wantAttempts: 3},
+		{name: "404 is permanent", statuses: []int{404, 200}, wantErr: true, wantStatus: 404, wantAttempts: 1},
+		{name: "401 is permanent", statuses: []int{401}, wantErr: true, wantStatus: 401, wantAttempts: 1},
+		{name: "exhausts attempts", statuses: []int{500, 500, 500, 500, 500}, wantErr: true, wantStatus: 500, wantAttempts: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.URL.Query().Get("api_key"); got != "test-key" {
+					t.Errorf("api_key = %q, want test-key", got)
+				}
+				n := calls.Add(1)
+				status := tt.statuses[min(int(n), len(tt.statuses))-1]
+				if tt.retryAfter != "" {
+					w.Header().Set("Retry-After", tt.retryAfter)
+				}
+				w.WriteHeader(status)
+				w.Write([]byte(`{"ok":true}`))
+			}))
+			defer srv.Close()
+
+			body, err := testClient(srv.URL).Get(context.Background(), "/movie/1", nil)
+			if got := calls.Load(); got != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", got, tt.wantAttempts)
+			}
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if string(body) != `{"ok":true}` {
+					t.Errorf("body = %q", body)
+				}
+				return
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", apiErr.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestClientHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	var first atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			first.Store(time.Now().UnixNano())
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if waited := time.Since(time.Unix(0, first.Load())); waited < 900*time.Millisecond {
+			t.Errorf("retried after %s, want at least 1s", waited)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	f := testClient(srv.URL)
+	f.Retry.MaxDelay = 2 * time.Second
+	if _, err := f.Get(context.Background(), "/movie/1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestClientCapsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	// MaxDelay caps an hour-long Retry-After.
+	start := time.Now()
+	if _, err := testClient(srv.URL).Get(context.Background(), "/movie/1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("retry took %s, want MaxDelay", elapsed)
+	}
+
+	// A delay past the deadline gives up at once rather than waiting for it.
+	calls.Store(0)
+	f := testClient(srv.URL)
+	f.Retry.MaxDelay = time.Minute
+	f.Retry.Deadline = 500 * time.Millisecond
+	start = time.Now()
+	if _, err := f.Get(context.Background(), "/movie/1", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("gave up after %s, want no waiting", elapsed)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("attempts = %d, want 1", got)
+	}
+}
+
+func TestBackoffCapsRetryAfter(t *testing.T) {
+	p := RetryPolicy{MaxAttempts: 3, MaxDelay: 30 * time.Second}
+	for retryAfter, want := range map[time.Duration]time.Duration{
+		5 * time.Second: 5 * time.Second,
+		time.Hour:       30 * time.Second,
+	} {
+		delay, ok := p.backoff(0, &APIError{StatusCode: http.StatusTooManyRequests, RetryAfter: retryAfter})
+		if !ok || delay != want {
+			t.Errorf("backoff with Retry-After %s = %s, %v; want %s, true", retryAfter, delay, ok, want)
+		}
+	}
+}
+
+func TestClientDeadline(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "10")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	f := testClient(srv.URL)
+	f.Retry.Deadline = 50 * time.Millisecond
+	start := time.Now()
+	_, err := f.Get(context.Background(), "/movie/1", nil)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("deadline not enforced, took %s", elapsed)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-3", 0},
+		{"garbage", 0},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second},
+		{now.Add(-30 * time.Second).Format(http.TimeFormat), 0},
+	}
+	for _, tt := range tests {
+		if got := parseRetryAfter(tt.value, now); got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestClientBearerToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q, want bearer token", got)
+		}
+		if r.URL.Query().Has("api_key") {
+			t.Error("api_key sent alongside a bearer token")
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	f := testClient(srv.URL)
+	f.Credentials.AccessToken = "test-token"
+	if _, err := f.Get(context.Background(), "/movie/1", nil); err != nil {
+		t.Fatal(err)
+	}
+}
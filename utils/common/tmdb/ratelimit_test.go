@@ -0,0 +1,80 @@
+package tmdb
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterBurstAndRefill(t *testing.T) {
+	l := NewRateLimiter(20, 3)
+	ctx := context.Background()
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("burst of 3 took %v, want no waiting", elapsed)
+	}
+
+	// The bucket is empty, so the next token takes 1/20s to refill.
+	start = time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond || elapsed > 500*time.Millisecond {
+		t.Errorf("wait for a refilled token took %v, want about 50ms", elapsed)
+	}
+
+	// Idle time refills up to burst and no further.
+	time.Sleep(300 * time.Millisecond)
+	start = time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 20*time.Millisecond {
+		t.Errorf("refilled burst took %v, want no waiting", elapsed)
+	}
+	start = time.Now()
+	if err := l.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("token beyond burst took %v, want about 50ms", elapsed)
+	}
+}
+
+func TestRateLimiterCancel(t *testing.T) {
+	l := NewRateLimiter(0.1, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// The next token is ten seconds away.
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := l.Wait(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait returned after %v, want soon after cancellation", elapsed)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	for name, l := range map[string]*RateLimiter{"nil": nil, "zero rate": NewRateLimiter(0, 1)} {
+		for i := 0; i < 100; i++ {
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatalf("%s: %v", name, err)
+			}
+		}
+	}
+}
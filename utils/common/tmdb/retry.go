@@ -0,0 +1,101 @@
+package tmdb
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// APIError is returned for any non-200 response.
+type APIError struct {
+	StatusCode int
+	Status     string
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API request failed with status: %s", e.Status)
+}
+
+// retryable reports whether the status code is worth another attempt.
+// Rate limiting and server-side failures are transient; every other 4xx
+// (bad key, unknown movie) will fail the same way again.
+func (e *APIError) retryable() bool {
+	switch e.StatusCode {
+	case http.StatusTooManyRequests, http.StatusRequestTimeout:
+		return true
+	}
+	return e.StatusCode >= 500
+}
+
+// RetryPolicy controls how Client retries failed requests.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+	// Deadline bounds a single logical request including all retries.
+	Deadline time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 5,
+	BaseDelay:   500 * time.Millisecond,
+	MaxDelay:    30 * time.Second,
+	Deadline:    2 * time.Minute,
+}
+
+// backoff returns how long to wait before the next attempt and whether one
+// should be made at all. attempt is zero-based. A server's Retry-After is
+// honored up to MaxDelay.
+func (p RetryPolicy) backoff(attempt int, err error) (time.Duration, bool) {
+	if attempt+1 >= p.MaxAttempts {
+		return 0, false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if !apiErr.retryable() {
+			return 0, false
+		}
+		if apiErr.RetryAfter > 0 {
+			if p.MaxDelay > 0 && apiErr.RetryAfter > p.MaxDelay {
+				return p.MaxDelay, true
+			}
+			return apiErr.RetryAfter, true
+		}
+	}
+
+	// Full jitter: a random delay up to the capped exponential step.
+	ceiling := p.BaseDelay << attempt
+	if ceiling <= 0 || ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0, true
+	}
+	return time.Duration(rand.Int64N(int64(ceiling))) + 1, true
+}
+
+// parseRetryAfter accepts either delta-seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0
+		}
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
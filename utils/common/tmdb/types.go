@@ -0,0 +1,240 @@
+package tmdb
+
+import (
+	"strings"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+// DiscoverResponse is one page of /discover/movie or /movie/popular.
+type DiscoverResponse struct {
+	Page         int            `json:"page"`
+	Results      []PopularMovie `json:"results"`
+	TotalPages   int            `json:"total_pages"`
+	TotalResults int            `json:"total_results"`
+}
+
+// PopularMovie is a movie stub as listed by the discover endpoints.
+type PopularMovie struct {
+	Adult            bool    `json:"adult"`
+	BackdropPath     string  `json:"backdrop_path"`
+	GenreIds         []int   `json:"genre_ids"`
+	ID               int     `json:"id"`
+	OriginalLanguage string  `json:"original_language"`
+	OriginalTitle    string  `json:"original_title"`
+	Overview         string  `json:"overview"`
+	Popularity       float64 `json:"popularity"`
+	PosterPath       string  `json:"poster_path"`
+	ReleaseDate      string  `json:"release_date"`
+	Title            string  `json:"title"`
+	Video            bool    `json:"video"`
+	VoteAverage      float64 `json:"vote_average"`
+	VoteCount        int     `json:"vote_count"`
+}
+
+// DetailsResponse is /movie/{id}.
+type DetailsResponse struct {
+	Adult               bool          `json:"adult"`
+	BackdropPath        string        `json:"backdrop_path"`
+	BelongsToCollection *Collection   `json:"belongs_to_collection"`
+	Budget              int           `json:"budget"`
+	Genres              []model.Genre `json:"genres"`
+	Homepage            string        `json:"homepage"`
+	ID                  int           `json:"id"`
+	ImdbID              string        `json:"imdb_id"`
+	OriginalLanguage    string        `json:"original_language"`
+	OriginalTitle       string        `json:"original_title"`
+	Overview            string        `json:"overview"`
+	Popularity          float64       `json:"popularity"`
+	PosterPath          string        `json:"poster_path"`
+	ProductionCompanies []struct {
+		ID            int    `json:"id"`
+		LogoPath      string `json:"logo_path"`
+		Name          string `json:"name"`
+		OriginCountry string `json:"origin_country"`
+	} `json:"production_companies"`
+	ProductionCountries []struct {
+		Iso31661 string `json:"iso_3166_1"`
+		Name     string `json:"name"`
+	} `json:"production_countries"`
+	ReleaseDate     string `json:"release_date"`
+	Revenue         int    `json:"revenue"`
+	Runtime         int    `json:"runtime"`
+	SpokenLanguages []struct {
+		EnglishName string `json:"english_name"`
+		Iso6391     string `json:"iso_639_1"`
+		Name        string `json:"name"`
+	} `json:"spoken_languages"`
+	Status      string  `json:"status"`
+	Tagline     string  `json:"tagline"`
+	Title       string  `json:"title"`
+	Video       bool    `json:"video"`
+	VoteAverage float64 `json:"vote_average"`
+	VoteCount   int     `json:"vote_count"`
+}
+
+// Collection is the franchise a movie belongs to, e.g. "Harry Potter
+// Collection".
+type Collection struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	PosterPath   string `json:"poster_path"`
+	BackdropPath string `json:"backdrop_path"`
+}
+
+// Model returns the collection as stored on a movie, or nil when the movie
+// belongs to none.
+func (c *Collection) Model() *model.Collection {
+	if c == nil {
+		return nil
+	}
+	return &model.Collection{ID: c.ID, Name: c.Name}
+}
+
+// CreditsResponse is /movie/{id}/credits.
+type CreditsResponse struct {
+	ID   int          `json:"id"`
+	Cast []CastMember `json:"cast"`
+	Crew []CrewMember `json:"crew"`
+}
+
+type CastMember struct {
+	Adult              bool    `json:"adult"`
+	Gender             int     `json:"gender"`
+	ID                 int     `json:"id"`
+	KnownForDepartment string  `json:"known_for_department"`
+	Name               string  `json:"name"`
+	OriginalName       string  `json:"original_name"`
+	Popularity         float64 `json:"popularity"`
+	ProfilePath        string  `json:"profile_path"`
+	CastID             int     `json:"cast_id"`
+	Character          string  `json:"character"`
+	CreditID           string  `json:"credit_id"`
+	Order              int     `json:"order"`
+}
+
+type CrewMember struct {
+	Adult              bool    `json:"adult"`
+	Gender             int     `json:"gender"`
+	ID                 int     `json:"id"`
+	KnownForDepartment string  `json:"known_for_department"`
+	Name               string  `json:"name"`
+	OriginalName       string  `json:"original_name"`
+	Popularity         float64 `json:"popularity"`
+	ProfilePath        string  `json:"profile_path"`
+	CreditID           string  `json:"credit_id"`
+	Department         string  `json:"department"`
+	Job                string  `json:"job"`
+}
+
+// Director returns the first crew member credited as director.
+func (c CreditsResponse) Director() (CrewMember, bool) {
+	for _, crew := range c.Crew {
+		if crew.Job == "Director" {
+			return crew, true
+		}
+	}
+	return CrewMember{}, false
+}
+
+type KeywordsResponse struct {
+	Keywords []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"keywords"`
+}
+
+// Names returns the keyword names in TMDB order.
+func (k KeywordsResponse) Names() []string {
+	var names []string
+	for _, kw := range k.Keywords {
+		names = append(names, kw.Name)
+	}
+	return names
+}
+
+type ReleaseDatesResponse struct {
+	Results []struct {
+		Iso31661     string `json:"iso_3166_1"`
+		ReleaseDates []struct {
+			Certification string `json:"certification"`
+			ReleaseDate   string `json:"release_date"`
+			Type          int    `json:"type"`
+		} `json:"release_dates"`
+	} `json:"results"`
+}
+
+// Certification returns the first non-empty certification for country.
+func (r ReleaseDatesResponse) Certification(country string) string {
+	for _, result := range r.Results {
+		if result.Iso31661 != country {
+			continue
+		}
+		for _, rd := range result.ReleaseDates {
+			if rd.Certification != "" {
+				return rd.Certification
+			}
+		}
+	}
+	return ""
+}
+
+type ExternalIDs struct {
+	ImdbID      string `json:"imdb_id"`
+	WikidataID  string `json:"wikidata_id"`
+	FacebookID  string `json:"facebook_id"`
+	InstagramID string `json:"instagram_id"`
+	TwitterID   string `json:"twitter_id"`
+}
+
+type AlternativeTitlesResponse struct {
+	Titles []struct {
+		Iso31661 string `json:"iso_3166_1"`
+		Title    string `json:"title"`
+		Type     string `json:"type"`
+	} `json:"titles"`
+}
+
+// Names returns the distinct titles used in any of countries, in TMDB order,
+// leaving out title itself.
+func (a AlternativeTitlesResponse) Names(title string, countries ...string) []string {
+	wanted := make(map[string]bool, len(countries))
+	for _, c := range countries {
+		wanted[c] = true
+	}
+	seen := map[string]bool{strings.ToLower(title): true}
+	var names []string
+	for _, t := range a.Titles {
+		key := strings.ToLower(t.Title)
+		if !wanted[t.Iso31661] || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, t.Title)
+	}
+	return names
+}
+
+// MovieAppendToResponse lists the sub-resources MovieResponse decodes.
+const MovieAppendToResponse = "credits,keywords,release_dates,external_ids,alternative_titles"
+
+// MovieResponse is /movie/{id} with every sub-resource the pipeline uses
+// appended via append_to_response, so each movie costs a single request.
+type MovieResponse struct {
+	DetailsResponse
+	Credits           CreditsResponse           `json:"credits"`
+	Keywords          KeywordsResponse          `json:"keywords"`
+	ReleaseDates      ReleaseDatesResponse      `json:"release_dates"`
+	ExternalIDs       ExternalIDs               `json:"external_ids"`
+	AlternativeTitles AlternativeTitlesResponse `json:"alternative_titles"`
+}
+
+// ChangesResponse is one page of /movie/changes.
+type ChangesResponse struct {
+	Results []struct {
+		ID    int  `json:"id"`
+		Adult bool `json:"adult"`
+	} `json:"results"`
+	Page       int `json:"page"`
+	TotalPages int `json:"total_pages"`
+}
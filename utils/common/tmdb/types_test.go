@@ -0,0 +1,59 @@
+package tmdb
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMovieResponseDecoding(t *testing.T) {
+	body := `{
+		"id": 137113,
+		"title": "Edge of Tomorrow",
+		"overview": "Major Bill Cage is caught in a time loop.",
+		"runtime": 113,
+		"credits": {
+			"cast": [{"id": 500, "name": "Tom Cruise", "character": "Cage", "order": 0}],
+			"crew": [
+				{"id": 1, "name": "Dion Beebe", "job": "Director of Photography"},
+				{"id": 2, "name": "Doug Liman", "job": "Director"}
+			]
+		},
+		"keywords": {"keywords": [{"id": 1, "name": "time loop"}, {"id": 2, "name": "alien invasion"}]},
+		"release_dates": {"results": [
+			{"iso_3166_1": "GB", "release_dates": [{"certification": "12A", "type": 3}]},
+			{"iso_3166_1": "US", "release_dates": [{"certification": "", "type": 1}, {"certification": "PG-13", "type": 3}]}
+		]},
+		"external_ids": {"imdb_id": "tt1631867"},
+		"alternative_titles": {"titles": [
+			{"iso_3166_1": "US", "title": "Live Die Repeat: Edge of Tomorrow", "type": "DVD title"},
+			{"iso_3166_1": "GB", "title": "live die repeat: edge of tomorrow", "type": ""},
+			{"iso_3166_1": "US", "title": "Edge of Tomorrow", "type": ""},
+			{"iso_3166_1": "FR", "title": "Edge of Tomorrow : Vivre, mourir, recommencer", "type": ""}
+		]}
+	}`
+	var resp MovieResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.ID != 137113 || resp.Runtime != 113 || resp.ExternalIDs.ImdbID != "tt1631867" {
+		t.Errorf("details = %d, %d, %q", resp.ID, resp.Runtime, resp.ExternalIDs.ImdbID)
+	}
+	if d, ok := resp.Credits.Director(); !ok || d.Name != "Doug Liman" {
+		t.Errorf("Director() = %v, %v, want Doug Liman", d.Name, ok)
+	}
+	if got, want := resp.Keywords.Names(), []string{"time loop", "alien invasion"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Keywords.Names() = %v, want %v", got, want)
+	}
+	if got := resp.ReleaseDates.Certification("US"); got != "PG-13" {
+		t.Errorf("Certification(US) = %q, want PG-13", got)
+	}
+	if got := resp.ReleaseDates.Certification("DE"); got != "" {
+		t.Errorf("Certification(DE) = %q, want none", got)
+	}
+	got := resp.AlternativeTitles.Names(resp.Title, "US", "GB")
+	if want := []string{"Live Die Repeat: Edge of Tomorrow"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AlternativeTitles.Names() = %v, want %v", got, want)
+	}
+}
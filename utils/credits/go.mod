@@ -1,3 +0,0 @@
-module credits
-
-go 1.23.1
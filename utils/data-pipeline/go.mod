@@ -1,3 +0,0 @@
-module data-pipeline
-
-go 1.23.1
@@ -1,94 +1,18 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
-	"net/url"
 	"os"
 	"strconv"
-	"time"
-)
-
-type PopularMovie struct {
-	Adult            bool    `json:"adult"`
-	BackdropPath     string  `json:"backdrop_path"`
-	GenreIds         []int   `json:"genre_ids"`
-	ID               int     `json:"id"`
-	OriginalLanguage string  `json:"original_language"`
-	OriginalTitle    string  `json:"original_title"`
-	Overview         string  `json:"overview"`
-	Popularity       float64 `json:"popularity"`
-	PosterPath       string  `json:"poster_path"`
-	ReleaseDate      string  `json:"release_date"`
-	Title            string  `json:"title"`
-	Video            bool    `json:"video"`
-	VoteAverage      float64 `json:"vote_average"`
-	VoteCount        int     `json:"vote_count"`
-}
-
-type Config struct {
-	TMDBKey string `json:"TMDBKey"`
-}
-
-type TMDBDetailsResponse struct {
-	Adult               bool        `json:"adult"`
-	BackdropPath        string      `json:"backdrop_path"`
-	BelongsToCollection interface{} `json:"belongs_to_collection"`
-	Budget              int         `json:"budget"`
-	Genres              []struct {
-		ID   int    `json:"id"`
-		Name string `json:"name"`
-	} `json:"genres"`
-	Homepage            string  `json:"homepage"`
-	ID                  int     `json:"id"`
-	ImdbID              string  `json:"imdb_id"`
-	OriginalLanguage    string  `json:"original_language"`
-	OriginalTitle       string  `json:"original_title"`
-	Overview            string  `json:"overview"`
-	Popularity          float64 `json:"popularity"`
-	PosterPath          string  `json:"poster_path"`
-	ProductionCompanies []struct {
-		ID            int    `json:"id"`
-		LogoPath      string `json:"logo_path"`
-		Name          string `json:"name"`
-		OriginCountry string `json:"origin_country"`
-	} `json:"production_companies"`
-	ProductionCountries []struct {
-		Iso31661 string `json:"iso_3166_1"`
-		Name     string `json:"name"`
-	} `json:"production_countries"`
-	ReleaseDate     string `json:"release_date"`
-	Revenue         int    `json:"revenue"`
-	Runtime         int    `json:"runtime"`
-	SpokenLanguages []struct {
-		EnglishName string `json:"english_name"`
-		Iso6391     string `json:"iso_639_1"`
-		Name        string `json:"name"`
-	} `json:"spoken_languages"`
-	Status      string  `json:"status"`
-	Tagline     string  `json:"tagline"`
-	Title       string  `json:"title"`
-	Video       bool    `json:"video"`
-	VoteAverage float64 `json:"vote_average"`
-	VoteCount   int     `json:"vote_count"`
-}
-
-func TMDBKey() string {
-	configFile, err := os.Open("../secrets.json")
-	if err != nil {
-		fmt.Println(err)
-	}
-	defer configFile.Close()
 
-	secretsBytes, _ := io.ReadAll(configFile)
-	var config Config
-	json.Unmarshal(secretsBytes, &config)
-	return config.TMDBKey
-}
+	"github.com/unrealities/talkie-trivia/utils/common/credentials"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+)
 
-func URLS() []string {
+func movieIDs() []int {
 	fileName := "../fetchPopularMovies/popular_movies_raw.json"
 	moviesFile, err := os.Open(fileName)
 	if err != nil {
@@ -97,69 +21,66 @@ func URLS() []string {
 	defer moviesFile.Close()
 
 	byteValue, _ := io.ReadAll(moviesFile)
-	var movies []PopularMovie
+	var movies []tmdb.PopularMovie
 	json.Unmarshal(byteValue, &movies)
 
-	urls := make([]string, len(movies))
-
+	var ids []int
 	for _, movie := range movies {
 		if movie.OriginalLanguage != "en" {
 			continue
 		}
-		path := "/3/movie/" + strconv.Itoa(movie.ID)
-		url := url.URL{
-			Scheme: "https",
-			Host:   "api.themoviedb.org",
-			Path:   path,
-		}
-		q := url.Query()
-		q.Set("api_key", TMDBKey())
-		url.RawQuery = q.Encode()
-		urls = append(urls, url.String())
+		ids = append(ids, movie.ID)
 	}
-	return urls
+	return ids
 }
 
 func WriteToFile(file *os.File, data string) {
 	dataToWrite := data + "\n"
 	_, err := file.WriteString(dataToWrite)
 	if err != nil {
-		fmt.Printf(err.Error())
+		fmt.Println(err)
 	}
 }
 
 func main() {
 	fileName := "movies.txt"
-	urls := URLS()
+
+	secrets, err := credentials.LoadSecrets("../secrets.json")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	creds, _, err := credentials.TMDB(tmdb.Credentials{}, secrets)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	client := tmdb.NewClient(creds)
+	client.Limiter = tmdb.NewRateLimiter(10, 1)
+
 	f, err := os.Create(fileName)
 	if err != nil {
-		fmt.Printf(err.Error())
+		fmt.Println(err)
+		return
 	}
 	defer f.Close()
 
-	for _, url := range urls {
-		if url == "" {
-			continue
-		}
-		resp, err := http.Get(url)
-		if err != nil {
-			fmt.Printf(err.Error())
-		}
-		body, err := io.ReadAll(resp.Body)
+	ctx := context.Background()
+	for _, id := range movieIDs() {
+		body, err := client.Get(ctx, "/movie/"+strconv.Itoa(id), nil)
 		if err != nil {
-			fmt.Printf(err.Error())
+			fmt.Println(err)
+			continue
 		}
 
-		data := (*json.RawMessage)(&body)
-		var validResponse TMDBDetailsResponse
-		err = json.Unmarshal(*data, &validResponse)
-		if err != nil {
-			fmt.Printf(err.Error())
+		var validResponse tmdb.DetailsResponse
+		if err := json.Unmarshal(body, &validResponse); err != nil {
+			fmt.Println(err)
+			continue
 		}
 
 		if validResponse.OriginalTitle > "" {
 			WriteToFile(f, string(body))
 		}
-		time.Sleep(100 * time.Millisecond)
 	}
 }
@@ -1,73 +1,18 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
-	"net/url"
 	"os"
 	"strconv"
-	"time"
-)
-
-type PopularMovie struct {
-	Adult         bool    `json:"adult"`
-	ID            int     `json:"id"`
-	OriginalTitle string  `json:"original_title"`
-	Popularity    float64 `json:"popularity"`
-	Video         bool    `json:"video"`
-}
-
-type Config struct {
-	TMDBKey string `json:"TMDBKey"`
-}
-
-type TMDBCreditsResponse struct {
-	ID   int `json:"id"`
-	Cast []struct {
-		Adult              bool    `json:"adult"`
-		Gender             int     `json:"gender"`
-		ID                 int     `json:"id"`
-		KnownForDepartment string  `json:"known_for_department"`
-		Name               string  `json:"name"`
-		OriginalName       string  `json:"original_name"`
-		Popularity         float64 `json:"popularity"`
-		ProfilePath        string  `json:"profile_path"`
-		CastID             int     `json:"cast_id"`
-		Character          string  `json:"character"`
-		CreditID           string  `json:"credit_id"`
-		Order              int     `json:"order"`
-	} `json:"cast"`
-	Crew []struct {
-		Adult              bool    `json:"adult"`
-		Gender             int     `json:"gender"`
-		ID                 int     `json:"id"`
-		KnownForDepartment string  `json:"known_for_department"`
-		Name               string  `json:"name"`
-		OriginalName       string  `json:"original_name"`
-		Popularity         float64 `json:"popularity"`
-		ProfilePath        string  `json:"profile_path"`
-		CreditID           string  `json:"credit_id"`
-		Department         string  `json:"department"`
-		Job                string  `json:"job"`
-	} `json:"crew"`
-}
-
-func TMDBKey() string {
-	configFile, err := os.Open("../secrets.json")
-	if err != nil {
-		fmt.Println(err)
-	}
-	defer configFile.Close()
 
-	secretsBytes, _ := io.ReadAll(configFile)
-	var config Config
-	json.Unmarshal(secretsBytes, &config)
-	return config.TMDBKey
-}
+	"github.com/unrealities/talkie-trivia/utils/common/credentials"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+)
 
-func URLS() []string {
+func movieIDs() []int {
 	moviesFile, err := os.Open("../../data/movies.json")
 	if err != nil {
 		fmt.Println(err)
@@ -75,24 +20,14 @@ func URLS() []string {
 	defer moviesFile.Close()
 
 	byteValue, _ := io.ReadAll(moviesFile)
-	var movies []PopularMovie
+	var movies []tmdb.DetailsResponse
 	json.Unmarshal(byteValue, &movies)
 
-	urls := make([]string, len(movies))
-
+	ids := make([]int, 0, len(movies))
 	for _, movie := range movies {
-		path := "/3/movie/" + strconv.Itoa(movie.ID) + "/credits"
-		url := url.URL{
-			Scheme: "https",
-			Host:   "api.themoviedb.org",
-			Path:   path,
-		}
-		q := url.Query()
-		q.Set("api_key", TMDBKey())
-		url.RawQuery = q.Encode()
-		urls = append(urls, url.String())
+		ids = append(ids, movie.ID)
 	}
-	return urls
+	return ids
 }
 
 func WriteToFile(file *os.File, data string) {
@@ -105,37 +40,43 @@ func WriteToFile(file *os.File, data string) {
 
 func main() {
 	fileName := "credits.txt"
-	urls := URLS()
+
+	secrets, err := credentials.LoadSecrets("../secrets.json")
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	creds, _, err := credentials.TMDB(tmdb.Credentials{}, secrets)
+	if err != nil {
+		fmt.Println(err.Error())
+		return
+	}
+	client := tmdb.NewClient(creds)
+	client.Limiter = tmdb.NewRateLimiter(10, 1)
+
 	f, err := os.Create(fileName)
 	if err != nil {
 		fmt.Println(err.Error())
+		return
 	}
 	defer f.Close()
 
-	for _, url := range urls {
-		if url == "" {
-			continue
-		}
-		resp, err := http.Get(url)
-		if err != nil {
-			fmt.Println(err.Error())
-		}
-		body, err := io.ReadAll(resp.Body)
+	ctx := context.Background()
+	for _, id := range movieIDs() {
+		body, err := client.Get(ctx, "/movie/"+strconv.Itoa(id)+"/credits", nil)
 		if err != nil {
 			fmt.Println(err.Error())
+			continue
 		}
 
-		data := (*json.RawMessage)(&body)
-		var validResponse TMDBCreditsResponse
-		err = json.Unmarshal(*data, &validResponse)
-		if err != nil {
+		var validResponse tmdb.CreditsResponse
+		if err := json.Unmarshal(body, &validResponse); err != nil {
 			fmt.Println(err.Error())
+			continue
 		}
 
 		if validResponse.ID > 0 {
 			WriteToFile(f, string(body))
 		}
-
-		time.Sleep(100 * time.Millisecond)
 	}
 }
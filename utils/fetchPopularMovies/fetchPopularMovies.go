@@ -1,124 +1,62 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
 	"strconv"
-	"time"
-)
-
-type Movie struct {
-	Adult            bool    `json:"adult"`
-	BackdropPath     string  `json:"backdrop_path"`
-	GenreIds         []int   `json:"genre_ids"`
-	ID               int     `json:"id"`
-	OriginalLanguage string  `json:"original_language"`
-	OriginalTitle    string  `json:"original_title"`
-	Overview         string  `json:"overview"`
-	Popularity       float64 `json:"popularity"`
-	PosterPath       string  `json:"poster_path"`
-	ReleaseDate      string  `json:"release_date"`
-	Title            string  `json:"title"`
-	Video            bool    `json:"video"`
-	VoteAverage      float64 `json:"vote_average"`
-	VoteCount        int     `json:"vote_count"`
-}
 
-type Config struct {
-	TMDBKey string `json:"TMDBKey"`
-}
+	"github.com/unrealities/talkie-trivia/utils/common/credentials"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+)
 
-type TMDBMoviesReponse struct {
-	Page         int     `json:"page"`
-	Results      []Movie `json:"results"`
-	TotalPages   int     `json:"total_pages"`
-	TotalResults int     `json:"total_results"`
-}
+func main() {
+	fileName := "popular_movies_raw.json"
+	max_pages := 500
 
-func TMDBKey() string {
-	configFile, err := os.Open("../secrets.json")
+	secrets, err := credentials.LoadSecrets("../secrets.json")
 	if err != nil {
 		fmt.Println(err)
+		return
 	}
-	defer configFile.Close()
-
-	secretsBytes, _ := io.ReadAll(configFile)
-	var config Config
-	json.Unmarshal(secretsBytes, &config)
-	return config.TMDBKey
-}
-
-func URLS(pages int) []string {
-	urls := make([]string, pages)
-
-	for page := 1; page < pages; page++ {
-		path := "/3/discover/movie"
-		url := url.URL{
-			Scheme: "https",
-			Host:   "api.themoviedb.org",
-			Path:   path,
-		}
-		q := url.Query()
-		q.Set("page", strconv.Itoa(page))
-		q.Set("include_adult", "false")
-		q.Set("include_video", "false")
-		q.Set("language", "en-US")
-		q.Set("sort_by", "popularity.desc")
-		q.Set("api_key", TMDBKey())
-		url.RawQuery = q.Encode()
-		urls = append(urls, url.String())
-	}
-	return urls
-}
-
-func WriteToFile(file *os.File, data string) {
-	dataToWrite := data + "\n"
-	_, err := file.WriteString(dataToWrite)
+	creds, _, err := credentials.TMDB(tmdb.Credentials{}, secrets)
 	if err != nil {
-		fmt.Printf(err.Error())
+		fmt.Println(err)
+		return
 	}
-}
+	client := tmdb.NewClient(creds)
+	client.Limiter = tmdb.NewRateLimiter(10, 1)
 
-func main() {
-	fileName := "popular_movies_raw.json"
-	max_pages := 500
-	urls := URLS(max_pages)
 	f, err := os.Create(fileName)
 	if err != nil {
-		fmt.Printf(err.Error())
+		fmt.Println(err)
+		return
 	}
 	defer f.Close()
 
-	results := []Movie{}
-
-	for _, url := range urls {
-		if url == "" {
-			continue
-		}
-		resp, err := http.Get(url)
+	ctx := context.Background()
+	results := []tmdb.PopularMovie{}
+
+	for page := 1; page < max_pages; page++ {
+		var validResponse tmdb.DiscoverResponse
+		err := client.GetJSON(ctx, "/discover/movie", map[string]string{
+			"page":          strconv.Itoa(page),
+			"include_adult": "false",
+			"include_video": "false",
+			"language":      "en-US",
+			"sort_by":       "popularity.desc",
+		}, &validResponse)
 		if err != nil {
-			fmt.Printf(err.Error())
-		}
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			fmt.Printf(err.Error())
-		}
-
-		data := (*json.RawMessage)(&body)
-		var validResponse TMDBMoviesReponse
-		err = json.Unmarshal(*data, &validResponse)
-		if err != nil {
-			fmt.Printf(err.Error())
+			fmt.Println(err)
+			continue
 		}
 
 		results = append(results, validResponse.Results...)
-		time.Sleep(100 * time.Millisecond)
 	}
 
 	jsonResults, _ := json.Marshal(results)
-	WriteToFile(f, string(jsonResults))
+	if _, err := f.WriteString(string(jsonResults) + "\n"); err != nil {
+		fmt.Println(err)
+	}
 }
@@ -1,3 +0,0 @@
-module movies
-
-go 1.23.1
@@ -0,0 +1,316 @@
+// Package audit scans sanitized overviews and taglines for words that may
+// still give the answer away, and ranks the movies by how badly they leak.
+package audit
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+	"github.com/unrealities/talkie-trivia/utils/talkie/sanitize"
+)
+
+// Sources of a leak: which part of the movie's metadata a word points to.
+const (
+	SourceTitle      = "title"
+	SourceCollection = "collection"
+	SourceCharacter  = "character"
+	SourceCast       = "cast"
+	SourceDirector   = "director"
+	SourceYear       = "year"
+	SourceProperNoun = "proper-noun"
+)
+
+// Ways a word can match a metadata term.
+const (
+	MatchExact = "exact"
+	MatchStem  = "stem"
+	MatchFuzzy = "fuzzy"
+)
+
+// sourceWeights rank how much a leak from each source gives away. A title
+// word all but names the movie; a rare proper noun only narrows it down.
+var sourceWeights = map[string]float64{
+	SourceTitle:      5,
+	SourceCollection: 4,
+	SourceCharacter:  4,
+	SourceCast:       3,
+	SourceDirector:   3,
+	SourceYear:       2,
+	SourceProperNoun: 1,
+}
+
+var matchWeights = map[string]float64{MatchExact: 1, MatchStem: 0.8, MatchFuzzy: 0.6}
+
+// Fields of a movie the audit scans.
+const (
+	FieldOverview = "overview"
+	FieldTagline  = "tagline"
+)
+
+// Finding is one suspected leak in the overview or tagline. Start and End
+// are byte offsets into that field.
+type Finding struct {
+	Field  string  `json:"field"`
+	Source string  `json:"source"`
+	Match  string  `json:"match"`
+	Term   string  `json:"term"`
+	Text   string  `json:"text"`
+	Start  int     `json:"start"`
+	End    int     `json:"end"`
+	Score  float64 `json:"score"`
+}
+
+// MovieReport is the audit of one movie's overview and tagline.
+type MovieReport struct {
+	ID       int       `json:"id"`
+	Title    string    `json:"title"`
+	Overview string    `json:"overview"`
+	Tagline  string    `json:"tagline,omitempty"`
+	Score    float64   `json:"score"`
+	Findings []Finding `json:"findings"`
+}
+
+// Text returns the audited text of a field.
+func (m MovieReport) Text(field string) string {
+	if field == FieldTagline {
+		return m.Tagline
+	}
+	return m.Overview
+}
+
+// Report lists the movies with suspected leaks, worst first.
+type Report struct {
+	Movies []MovieReport `json:"movies"`
+}
+
+// Run audits popularMovies.json in dataDir.
+func Run(dataDir string) (*Report, error) {
+	var movies []model.Movie
+	if err := datafile.Read(filepath.Join(dataDir, datafile.PopularMovies), &movies); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", datafile.PopularMovies, err)
+	}
+	return Audit(movies), nil
+}
+
+// Audit scans the sanitized overview and tagline of every movie and ranks
+// the movies with findings by their total score.
+func Audit(movies []model.Movie) *Report {
+	frequency := properNounFrequency(movies)
+	r := &Report{Movies: []MovieReport{}}
+	for _, m := range movies {
+		mr := auditMovie(m, frequency)
+		if len(mr.Findings) > 0 {
+			r.Movies = append(r.Movies, mr)
+		}
+	}
+	sort.SliceStable(r.Movies, func(i, j int) bool {
+		if r.Movies[i].Score != r.Movies[j].Score {
+			return r.Movies[i].Score > r.Movies[j].Score
+		}
+		return r.Movies[i].ID < r.Movies[j].ID
+	})
+	return r
+}
+
+// term is a sensitive metadata word and where it came from.
+type term struct {
+	source string
+	word   string
+	stem   string
+}
+
+func auditMovie(m model.Movie, frequency map[string]int) MovieReport {
+	terms := metadataTerms(m)
+	year := ""
+	if len(m.ReleaseDate) >= 4 {
+		year = m.ReleaseDate[:4]
+	}
+
+	mr := MovieReport{ID: m.ID, Title: m.Title, Overview: m.Overview, Tagline: m.Tagline}
+	for _, field := range []string{FieldOverview, FieldTagline} {
+		text := mr.Text(field)
+		tokens := sanitize.Tokens(text)
+		for i, tok := range tokens {
+			if sanitize.IsPlaceholder(text, tok) {
+				continue
+			}
+			best, ok := matchTerms(tok.Folded, terms)
+			if !ok {
+				best, ok = matchYear(tok.Folded, year)
+			}
+			// Taglines are often in title case, so capitalization says
+			// nothing about names there.
+			if !ok && field == FieldOverview && significant(tok.Folded) && sanitize.ProperNoun(text, tokens, i) && frequency[tok.Folded] == 1 {
+				best, ok = Finding{Source: SourceProperNoun, Match: MatchExact, Term: tok.Folded}, true
+			}
+			if !ok {
+				continue
+			}
+			best.Field, best.Text, best.Start, best.End = field, tok.Text, tok.Start, tok.End
+			best.Score = sourceWeights[best.Source] * matchWeights[best.Match]
+			mr.Findings = append(mr.Findings, best)
+			mr.Score += best.Score
+		}
+	}
+	return mr
+}
+
+// metadataTerms collects the words of m's metadata that should never appear
+// in its overview.
+func metadataTerms(m model.Movie) []term {
+	var terms []term
+	add := func(source, text string) {
+		for _, tok := range sanitize.Tokens(text) {
+			if significant(tok.Folded) {
+				terms = append(terms, term{source: source, word: tok.Folded, stem: stem(tok.Folded)})
+			}
+		}
+	}
+	add(SourceTitle, m.Title)
+	for _, title := range m.AltTitles {
+		add(SourceTitle, title)
+	}
+	if m.Collection != nil {
+		add(SourceCollection, m.Collection.Name)
+	}
+	for _, a := range m.Actors {
+		add(SourceCharacter, a.Character)
+		add(SourceCast, a.Name)
+	}
+	add(SourceDirector, m.Director.Name)
+	return terms
+}
+
+// significant reports whether a folded word is worth matching on its own.
+func significant(word string) bool {
+	return utf8.RuneCountInString(word) > 2 && !commonWords[word]
+}
+
+// commonWords are ordinary words that metadata shares with most overviews.
+var commonWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "from": true, "part": true, "iii": true,
+	"his": true, "her": true, "man": true, "one": true, "two": true, "who": true, "new": true,
+	"young": true, "old": true, "himself": true, "herself": true, "self": true, "voice": true,
+	"uncredited": true, "collection": true,
+}
+
+// matchTerms returns the best match of a folded overview word against the
+// metadata terms: exact beats stemmed beats fuzzy, then by source weight.
+func matchTerms(word string, terms []term) (Finding, bool) {
+	if !significant(word) {
+		return Finding{}, false
+	}
+	var best Finding
+	bestScore := 0.0
+	wordStem := stem(word)
+	for _, t := range terms {
+		match := ""
+		switch {
+		case word == t.word:
+			match = MatchExact
+		case wordStem == t.stem:
+			match = MatchStem
+		case fuzzy(word, t.word):
+			match = MatchFuzzy
+		default:
+			continue
+		}
+		if score := sourceWeights[t.source] * matchWeights[match]; score > bestScore {
+			best, bestScore = Finding{Source: t.source, Match: match, Term: t.word}, score
+		}
+	}
+	return best, bestScore > 0
+}
+
+// matchYear flags the release year, and its decade as a stemmed match.
+func matchYear(word, year string) (Finding, bool) {
+	if year == "" {
+		return Finding{}, false
+	}
+	switch {
+	case word == year:
+		return Finding{Source: SourceYear, Match: MatchExact, Term: year}, true
+	case word == year[:3]+"0s":
+		return Finding{Source: SourceYear, Match: MatchStem, Term: year}, true
+	}
+	return Finding{}, false
+}
+
+// properNounFrequency counts, for every capitalized word in the overviews,
+// titles and character credits, how many movies mention it. A proper noun
+// mentioned by a single movie points straight at it.
+func properNounFrequency(movies []model.Movie) map[string]int {
+	frequency := make(map[string]int)
+	for _, m := range movies {
+		seen := make(map[string]bool)
+		texts := []string{m.OriginalOverview, m.Overview, m.Title, m.Tagline}
+		for _, a := range m.Actors {
+			texts = append(texts, a.Character)
+		}
+		for _, text := range texts {
+			for _, tok := range sanitize.Tokens(text) {
+				first, _ := utf8.DecodeRuneInString(tok.Text)
+				if unicode.IsUpper(first) {
+					seen[tok.Folded] = true
+				}
+			}
+		}
+		for word := range seen {
+			frequency[word]++
+		}
+	}
+	return frequency
+}
+
+// stem strips common English inflections, so "Avengers" matches "Avenger"
+// and "Potter's" matches "Potter".
+func stem(word string) string {
+	word = strings.TrimSuffix(word, "'s")
+	for _, suffix := range []string{"ings", "ing", "ers", "er", "ed", "es", "s", "ly"} {
+		if strings.HasSuffix(word, suffix) && utf8.RuneCountInString(word)-len(suffix) >= 3 {
+			return strings.TrimSuffix(word, suffix)
+		}
+	}
+	return word
+}
+
+// fuzzy reports whether two longer words are within a typo of each other:
+// one edit for words of five or more letters, two from eight.
+func fuzzy(a, b string) bool {
+	n := min(utf8.RuneCountInString(a), utf8.RuneCountInString(b))
+	switch {
+	case n >= 8:
+		return distance(a, b) <= 2
+	case n >= 5:
+		return distance(a, b) <= 1
+	}
+	return false
+}
+
+// distance is the Levenshtein distance between a and b in runes.
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur := make([]int, len(rb)+1)
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(rb)]
+}
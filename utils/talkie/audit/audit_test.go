@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func TestAudit(t *testing.T) {
+	movies := []model.Movie{
+		{
+			ID:          1,
+			Title:       "Endgame",
+			ReleaseDate: "2019-04-24",
+			Collection:  &model.Collection{ID: 86311, Name: "The Avengers Collection"},
+			Actors:      []model.MovieActor{{Name: "Robert Downey Jr.", Character: "Tony Stark / Iron Man"}},
+			Director:    model.MovieDirector{Name: "Anthony Russo"},
+			Overview:    "In 2019, the Avenger team led by [Protagonist] [Protagonist] regroups after Thanos wiped out half of New York.",
+		},
+		{
+			ID:          2,
+			Title:       "Heat",
+			ReleaseDate: "1995-12-15",
+			Overview:    "Obsessive master thief [Protagonist] leads a crew in 1990s New York, hunted by a detective.",
+			Tagline:     "A Los Angeles Crime Saga",
+		},
+		{
+			ID:          3,
+			Title:       "Spider-Man: Into the Spider-Verse",
+			ReleaseDate: "2018-12-06",
+			Actors:      []model.MovieActor{{Name: "Shameik Moore", Character: "Miles Morales"}},
+			Overview:    "Teen Mles Morales becomes the Spidr-Man of his universe in New York.",
+			Tagline:     "More Than One Wears the Spider Mask",
+		},
+		{ID: 4, Title: "Clean", ReleaseDate: "2000-01-01", Overview: "Nothing to see here."},
+	}
+
+	r := Audit(movies)
+
+	var ids []int
+	for _, m := range r.Movies {
+		ids = append(ids, m.ID)
+	}
+	if want := []int{3, 1, 2}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("ranked movies = %v, want %v", ids, want)
+	}
+
+	findings := func(m MovieReport) []string {
+		var got []string
+		for _, f := range m.Findings {
+			got = append(got, f.Field+":"+f.Text+":"+f.Source+"/"+f.Match)
+		}
+		return got
+	}
+	for i, want := range [][]string{
+		{"overview:Mles:proper-noun/exact", "overview:Morales:character/exact", "overview:Spidr:title/fuzzy", "tagline:Spider:title/exact"},
+		{"overview:2019:year/exact", "overview:Avenger:collection/stem", "overview:Thanos:proper-noun/exact"},
+		// Capitalized words in the tagline are not taken for names.
+		{"overview:1990s:year/stem"},
+	} {
+		if got := findings(r.Movies[i]); !reflect.DeepEqual(got, want) {
+			t.Errorf("movie %d findings = %v, want %v", r.Movies[i].ID, got, want)
+		}
+	}
+}
+
+func TestReportFormats(t *testing.T) {
+	r := Audit([]model.Movie{{ID: 7, Title: "Jaws", ReleaseDate: "1975-06-20", Overview: "A shark <terrorizes> the town in 1975."}})
+
+	var buf bytes.Buffer
+	if err := r.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d CSV rows, want header and 1 finding", len(rows))
+	}
+	if got, want := rows[1][len(rows[1])-1], "A shark <terrorizes> the town in «1975»."; got != want {
+		t.Errorf("context = %q, want %q", got, want)
+	}
+
+	buf.Reset()
+	if err := r.WriteHTML(&buf); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"A shark &lt;terrorizes&gt; the town in <mark class=\"year\"",
+		">1975</mark>.",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("HTML report missing %q:\n%s", want, buf.String())
+		}
+	}
+}
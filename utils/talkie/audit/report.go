@@ -0,0 +1,127 @@
+package audit
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"math"
+	"strconv"
+)
+
+// WriteCSV writes one row per finding, ranked like the report. The context
+// column is the overview or tagline with the offending span between « and ».
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"rank", "id", "title", "movie_score", "field", "source", "match", "term", "text", "start", "end", "score", "context"})
+	for i, m := range r.Movies {
+		for _, f := range m.Findings {
+			cw.Write([]string{
+				strconv.Itoa(i + 1),
+				strconv.Itoa(m.ID),
+				m.Title,
+				formatScore(m.Score),
+				f.Field,
+				f.Source,
+				f.Match,
+				f.Term,
+				f.Text,
+				strconv.Itoa(f.Start),
+				strconv.Itoa(f.End),
+				formatScore(f.Score),
+				context(m.Text(f.Field), f),
+			})
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func context(text string, f Finding) string {
+	return text[:f.Start] + "«" + f.Text + "»" + text[f.End:]
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(math.Round(score*100)/100, 'f', -1, 64)
+}
+
+// segment is a run of overview or tagline text, highlighted when it is a
+// finding.
+type segment struct {
+	Text    string
+	Finding *Finding
+}
+
+func (m MovieReport) segments(field string) []segment {
+	text := m.Text(field)
+	var segments []segment
+	last := 0
+	for i := range m.Findings {
+		f := &m.Findings[i]
+		if f.Field != field {
+			continue
+		}
+		segments = append(segments, segment{Text: text[last:f.Start]}, segment{Text: f.Text, Finding: f})
+		last = f.End
+	}
+	return append(segments, segment{Text: text[last:]})
+}
+
+var htmlReport = template.Must(template.New("audit").Funcs(template.FuncMap{
+	"score": formatScore,
+	"inc":   func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Overview leak audit</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border-bottom: 1px solid #ddd; padding: 0.5em; text-align: left; vertical-align: top; }
+td.overview { line-height: 1.5; }
+mark { padding: 0 0.15em; border-radius: 0.2em; }
+mark.title, mark.collection { background: #f8a5a5; }
+mark.character, mark.cast, mark.director { background: #f8d38a; }
+mark.year { background: #b9d8f8; }
+mark.proper-noun { background: #d8f0b0; }
+</style>
+</head>
+<body>
+<h1>Overview leak audit</h1>
+<p>{{len .Movies}} movies with suspected leaks, worst first. Hover a highlight for what it matched.</p>
+<table>
+<tr><th>#</th><th>Movie</th><th>Score</th><th>Sanitized overview and tagline</th></tr>
+{{range $i, $m := .Movies}}<tr>
+<td>{{inc $i}}</td>
+<td>{{$m.Title}}<br><small>{{$m.ID}}</small></td>
+<td>{{score $m.Score}}</td>
+<td class="overview">{{template "segments" $m.Overview}}{{if $m.Tagline.Segments}}<br><em>{{template "segments" $m.Tagline}}</em>{{end}}</td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+{{define "segments"}}{{range .Segments}}{{if .Finding}}<mark class="{{.Finding.Source}}" title="{{.Finding.Source}}, {{.Finding.Match}} match for &quot;{{.Finding.Term}}&quot;">{{.Text}}</mark>{{else}}{{.Text}}{{end}}{{end}}{{end}}
+`))
+
+// WriteHTML writes the report as a standalone page with every finding
+// highlighted in its overview.
+func (r *Report) WriteHTML(w io.Writer) error {
+	type text struct{ Segments []segment }
+	type row struct {
+		MovieReport
+		Overview, Tagline text
+	}
+	var rows []row
+	for _, m := range r.Movies {
+		tagline := text{}
+		if m.Tagline != "" {
+			tagline.Segments = m.segments(FieldTagline)
+		}
+		rows = append(rows, row{m, text{m.segments(FieldOverview)}, tagline})
+	}
+	if err := htmlReport.Execute(w, struct{ Movies []row }{rows}); err != nil {
+		return fmt.Errorf("failed to render report: %w", err)
+	}
+	return nil
+}
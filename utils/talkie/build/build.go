@@ -0,0 +1,119 @@
+// Package build assembles popularMovies.json and basicMovies.json from a raw
+// TMDB details dump (movies.json) and the cast and director maps written by
+// the credits step, without touching the network.
+package build
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+
+	"github.com/unrealities/talkie-trivia/utils/common/filters"
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+	"github.com/unrealities/talkie-trivia/utils/talkie/diff"
+	"github.com/unrealities/talkie-trivia/utils/talkie/pipeline"
+	"github.com/unrealities/talkie-trivia/utils/talkie/sanitize"
+)
+
+// Options configures a build.
+type Options struct {
+	DataDir string
+	// MovieGate selects the movies for popularMovies.json and PickerGate
+	// the broader list offered in the answer picker (basicMovies.json).
+	MovieGate  *filters.Gate
+	PickerGate *filters.Gate
+	// Diff, when set, compares the outputs with the files on disk instead of
+	// writing them.
+	Diff *diff.Report
+}
+
+// Run reads movies.json, movieActors.json and movieDirectors.json from the
+// data directory and writes popularMovies.json and basicMovies.json.
+func Run(opts Options) error {
+	var actors map[int][]model.MovieActor
+	if err := datafile.Read(filepath.Join(opts.DataDir, datafile.MovieActors), &actors); err != nil {
+		return err
+	}
+	var directors map[int]model.MovieDirector
+	if err := datafile.Read(filepath.Join(opts.DataDir, datafile.MovieDirectors), &directors); err != nil {
+		return err
+	}
+	var movies []tmdb.DetailsResponse
+	if err := datafile.Read(filepath.Join(opts.DataDir, datafile.Movies), &movies); err != nil {
+		return err
+	}
+
+	popularMovies := []model.Movie{}
+	pickerMovies := []model.Movie{}
+	for _, movie := range movies {
+		candidate := filters.Candidate{
+			Overview:    movie.Overview,
+			Runtime:     movie.Runtime,
+			Popularity:  movie.Popularity,
+			VoteAverage: movie.VoteAverage,
+			VoteCount:   movie.VoteCount,
+		}
+		// Movies without credits or genres still get empty lists, which the
+		// data file schemas require.
+		movieActors := actors[movie.ID]
+		if movieActors == nil {
+			movieActors = []model.MovieActor{}
+		}
+		genres := movie.Genres
+		if genres == nil {
+			genres = []model.Genre{}
+		}
+		m := model.Movie{
+			Actors:           movieActors,
+			Director:         directors[movie.ID],
+			Collection:       movie.BelongsToCollection.Model(),
+			Genres:           genres,
+			ImdbID:           movie.ImdbID,
+			ID:               movie.ID,
+			OriginalOverview: movie.Overview,
+			Popularity:       movie.Popularity,
+			PosterPath:       movie.PosterPath,
+			ReleaseDate:      movie.ReleaseDate,
+			OriginalTagline:  movie.Tagline,
+			Title:            movie.Title,
+			VoteAverage:      movie.VoteAverage,
+			VoteCount:        movie.VoteCount,
+		}
+		if opts.MovieGate.Allow(candidate) {
+			sort.SliceStable(m.Actors, func(i, j int) bool {
+				return m.Actors[i].Order < m.Actors[j].Order
+			})
+			sanitize.Movie(&m)
+			popularMovies = append(popularMovies, m)
+		}
+		if opts.PickerGate.Allow(candidate) {
+			pickerMovies = append(pickerMovies, m)
+		}
+	}
+
+	sanitize.AddOverviews(popularMovies)
+	pipeline.LogUnsafeTaglines(popularMovies)
+
+	for _, gate := range []*filters.Gate{opts.MovieGate, opts.PickerGate} {
+		for _, line := range gate.Report() {
+			slog.Info(line)
+		}
+	}
+
+	for _, out := range []struct {
+		name string
+		data interface{}
+	}{
+		{datafile.PopularMovies, popularMovies},
+		{datafile.BasicMovies, pipeline.BuildBasicMovies(pickerMovies)},
+	} {
+		if err := opts.Diff.Write(filepath.Join(opts.DataDir, out.name), out.data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", out.name, err)
+		}
+	}
+	slog.Info("Built movie files", "movies", len(popularMovies), "picker", len(pickerMovies))
+	return nil
+}
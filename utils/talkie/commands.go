@@ -0,0 +1,395 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/unrealities/talkie-trivia/utils/common/filters"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+	"github.com/unrealities/talkie-trivia/utils/talkie/audit"
+	"github.com/unrealities/talkie-trivia/utils/talkie/build"
+	"github.com/unrealities/talkie-trivia/utils/talkie/credits"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+	"github.com/unrealities/talkie-trivia/utils/talkie/diff"
+	"github.com/unrealities/talkie-trivia/utils/talkie/optimize"
+	"github.com/unrealities/talkie-trivia/utils/talkie/pipeline"
+	"github.com/unrealities/talkie-trivia/utils/talkie/populate"
+	"github.com/unrealities/talkie-trivia/utils/talkie/schedule"
+	"github.com/unrealities/talkie-trivia/utils/talkie/tsgen"
+	"github.com/unrealities/talkie-trivia/utils/talkie/validate"
+)
+
+func newFlagSet(name string) *flag.FlagSet {
+	return flag.NewFlagSet("talkie "+name, flag.ExitOnError)
+}
+
+// diffFlags are the dry-run flags shared by the commands that write
+// popularMovies.json and basicMovies.json.
+type diffFlags struct {
+	enabled   *bool
+	format    *string
+	out       *string
+	tolerance *float64
+}
+
+func addDiffFlags(fs *flag.FlagSet) *diffFlags {
+	return &diffFlags{
+		enabled:   fs.Bool("diff", false, "dry run: report how the outputs would change instead of writing them"),
+		format:    fs.String("diff-format", "text", "diff report format: text or json"),
+		out:       fs.String("diff-out", "", "write the diff report to this file instead of stdout"),
+		tolerance: fs.Float64("diff-tolerance", diff.DefaultTolerance, "relative change below which numeric fields count as unchanged"),
+	}
+}
+
+// report returns a diff report to collect into, or nil without -diff.
+func (d *diffFlags) report() (*diff.Report, error) {
+	if !*d.enabled {
+		return nil, nil
+	}
+	if *d.format != "text" && *d.format != "json" {
+		return nil, fmt.Errorf("invalid -diff-format %q", *d.format)
+	}
+	return diff.New(*d.tolerance), nil
+}
+
+func (d *diffFlags) write(r *diff.Report) error {
+	if r == nil {
+		return nil
+	}
+	w := io.Writer(os.Stdout)
+	if *d.out != "" {
+		f, err := os.Create(*d.out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if *d.format == "json" {
+		return r.WriteJSON(w)
+	}
+	return r.WriteText(w)
+}
+
+func loadGate(path, profile string) (*filters.Gate, error) {
+	filterConfig, err := filters.Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading filters: %w", err)
+	}
+	return filterConfig.Gate(profile)
+}
+
+func runFetch(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("fetch")
+	concurrency := fs.Int("concurrency", 8, "number of concurrent TMDB workers")
+	rps := fs.Float64("rps", 20, "maximum TMDB requests per second (0 disables limiting)")
+	maxAttempts := fs.Int("max-attempts", tmdb.DefaultRetryPolicy.MaxAttempts, "attempts per TMDB request before giving up")
+	deadline := fs.Duration("request-deadline", tmdb.DefaultRetryPolicy.Deadline, "overall deadline per TMDB request, including retries")
+	checkpointDir := fs.String("checkpoint-dir", g.workPath("checkpoint"), "directory for the resumable run journal")
+	resume := fs.Bool("resume", false, "continue from the journal in -checkpoint-dir instead of starting over")
+	cacheDir := fs.String("cache-dir", g.workPath("cache"), "directory for cached TMDB responses")
+	cacheTTL := fs.Duration("cache-ttl", 7*24*time.Hour, "serve cached responses younger than this without revalidating")
+	noCache := fs.Bool("no-cache", false, "bypass the response cache entirely")
+	offline := fs.Bool("offline", false, "serve every request from the cache and never touch the network")
+	incremental := fs.Bool("incremental", false, "refresh only movies changed on TMDB since the last recorded run")
+	stateFile := fs.String("state-file", g.workPath("runState.json"), "file recording when the last successful run started")
+	filtersPath := fs.String("filters", filepath.Join(g.utilsDir, filters.FileName), "filter config file")
+	profile := fs.String("profile", "daily", "filter profile to apply")
+	diffs := addDiffFlags(fs)
+	fs.Parse(args)
+
+	slog.Info("Starting data generation pipeline...")
+
+	report, err := diffs.report()
+	if err != nil {
+		return err
+	}
+
+	if *offline && *noCache {
+		return fmt.Errorf("-offline requires the response cache; drop -no-cache")
+	}
+	if *incremental && (*offline || *resume) {
+		return fmt.Errorf("-incremental cannot be combined with -offline or -resume")
+	}
+
+	gate, err := loadGate(*filtersPath, *profile)
+	if err != nil {
+		return err
+	}
+
+	// Offline runs never reach TMDB, so they need no credentials.
+	creds, err := g.tmdbCredentials()
+	if err != nil && !*offline {
+		return err
+	}
+
+	f := tmdb.NewClient(creds)
+	f.Limiter = tmdb.NewRateLimiter(*rps, *concurrency)
+	f.Retry.MaxAttempts = *maxAttempts
+	f.Retry.Deadline = *deadline
+	if !*noCache {
+		if f.Cache, err = tmdb.NewCache(*cacheDir, *cacheTTL, *offline); err != nil {
+			return fmt.Errorf("error opening response cache: %w", err)
+		}
+	}
+
+	// Cached data may be arbitrarily old, so offline runs never advance the
+	// recorded run time.
+	recordState := *stateFile
+	if *offline {
+		recordState = ""
+		slog.Info("Offline mode: serving all requests from the cache", "dir", *cacheDir)
+	} else {
+		slog.Info("Fetching from TMDB", "workers", *concurrency, "rps", *rps)
+	}
+
+	if err := pipeline.Run(ctx, pipeline.Options{
+		Client:        f,
+		Gate:          gate,
+		DataDir:       g.dataDir,
+		Workers:       *concurrency,
+		CheckpointDir: *checkpointDir,
+		Resume:        *resume,
+		Incremental:   *incremental,
+		StateFile:     recordState,
+		Diff:          report,
+	}); err != nil {
+		return err
+	}
+	return diffs.write(report)
+}
+
+func runCredits(ctx context.Context, g *globals, args []string) error {
+	newFlagSet("credits").Parse(args)
+	return credits.Run(g.dataDir)
+}
+
+func runBuild(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("build")
+	filtersPath := fs.String("filters", filepath.Join(g.utilsDir, filters.FileName), "filter config file")
+	profile := fs.String("profile", "daily", "filter profile for popularMovies.json")
+	pickerProfile := fs.String("picker-profile", "picker", "filter profile for basicMovies.json")
+	diffs := addDiffFlags(fs)
+	fs.Parse(args)
+
+	report, err := diffs.report()
+	if err != nil {
+		return err
+	}
+
+	movieGate, err := loadGate(*filtersPath, *profile)
+	if err != nil {
+		return err
+	}
+	pickerGate, err := loadGate(*filtersPath, *pickerProfile)
+	if err != nil {
+		return err
+	}
+	if err := build.Run(build.Options{DataDir: g.dataDir, MovieGate: movieGate, PickerGate: pickerGate, Diff: report}); err != nil {
+		return err
+	}
+	return diffs.write(report)
+}
+
+func runOptimize(ctx context.Context, g *globals, args []string) error {
+	newFlagSet("optimize").Parse(args)
+	return optimize.Run(g.dataDir)
+}
+
+func runPopulate(ctx context.Context, g *globals, args []string) error {
+	newFlagSet("populate").Parse(args)
+
+	slog.Info("Starting Firestore population...")
+	client, err := g.firestoreClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return populate.Run(ctx, client, g.dataDir)
+}
+
+func runSchedule(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("schedule")
+	days := fs.Int("days", schedule.DefaultDays, "number of days to schedule")
+	configPath := fs.String("config", filepath.Join(g.utilsDir, schedule.FileName), "schedule config file with the scheduling constraints")
+	rulesPath := fs.String("rules", filepath.Join(g.utilsDir, schedule.RulesFileName), "rules file with themed and seasonal days (empty for none)")
+	seed := fs.Int64("seed", 0, "seed for the planner, to reproduce an earlier schedule (0 picks one from the clock)")
+	startFlag := fs.String("start", "", "first date to schedule, as YYYY-MM-DD (default: the day after the last scheduled game)")
+	dryRun := fs.Bool("dry-run", false, "preview the schedule instead of writing it to Firestore")
+	format := fs.String("format", schedule.FormatCSV, "dry-run preview format: csv, json or ics")
+	out := fs.String("out", "", "write the dry-run preview to this file instead of stdout")
+	local := fs.Bool("local", false, "with -dry-run, plan from popularMovies.json in -data-dir instead of Firestore, without earlier games")
+	fs.Parse(args)
+
+	if *local && !*dryRun {
+		return fmt.Errorf("-local needs -dry-run")
+	}
+	if *format != schedule.FormatCSV && *format != schedule.FormatJSON && *format != schedule.FormatICS {
+		return fmt.Errorf("invalid -format %q", *format)
+	}
+
+	cfg, err := schedule.Load(*configPath)
+	if err != nil {
+		return err
+	}
+	var rules *schedule.Rules
+	if *rulesPath != "" {
+		if rules, err = schedule.LoadRules(*rulesPath); err != nil {
+			return err
+		}
+	}
+	var start time.Time
+	if *startFlag != "" {
+		if start, err = time.ParseInLocation("2006-01-02", *startFlag, time.Local); err != nil {
+			return fmt.Errorf("invalid -start: %w", err)
+		}
+	}
+
+	opts := schedule.Options{Days: *days, Config: cfg, Rules: rules, Seed: *seed, Start: start, DryRun: *dryRun, Preview: os.Stdout, PreviewFormat: *format}
+	if *dryRun && *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		opts.Preview = f
+	}
+
+	slog.Info("Starting daily games scheduling...")
+	var client *firestore.Client
+	if !*local {
+		client, err = g.firestoreClient(ctx)
+		if err == nil && *dryRun {
+			// An unreachable emulator makes every query retry forever, so a
+			// dry run checks first.
+			if err = probeFirestore(ctx, client); err != nil {
+				client.Close()
+				client = nil
+			}
+		}
+		switch {
+		case err == nil:
+			defer client.Close()
+		case *dryRun:
+			slog.Warn("No Firestore access; previewing from local movies instead", "err", err)
+		default:
+			return err
+		}
+	}
+	if client == nil {
+		path := filepath.Join(g.dataDir, datafile.PopularMovies)
+		if err := datafile.Read(path, &opts.Movies); err != nil {
+			return err
+		}
+		slog.Info("Read movies for the dry run", "file", path, "count", len(opts.Movies))
+	}
+	return schedule.Run(ctx, client, opts)
+}
+
+// probeFirestore reports whether client can read the movies collection
+// within a few seconds.
+func probeFirestore(ctx context.Context, client *firestore.Client) error {
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	_, err := client.Collection("movies").Limit(1).Documents(ctx).GetAll()
+	return err
+}
+
+func runValidate(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("validate")
+	version := fs.Int("schema-version", validate.SchemaVersion, "version of the data file schemas to validate against")
+	fs.Parse(args)
+
+	problems, err := validate.Check(g.dataDir, *version)
+	if err != nil {
+		return err
+	}
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%d problems found in %s", len(problems), g.dataDir)
+	}
+	slog.Info("Data files are valid", "dir", g.dataDir, "schemaVersion", *version)
+	return nil
+}
+
+func runAudit(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("audit")
+	format := fs.String("format", "csv", "report format: csv or html")
+	out := fs.String("out", "", "write the report to this file instead of stdout")
+	minScore := fs.Float64("min-score", 0, "only report movies whose leak score is at least this")
+	fs.Parse(args)
+
+	if *format != "csv" && *format != "html" {
+		return fmt.Errorf("invalid -format %q", *format)
+	}
+	report, err := audit.Run(g.dataDir)
+	if err != nil {
+		return err
+	}
+	movies := report.Movies[:0]
+	for _, m := range report.Movies {
+		if m.Score >= *minScore {
+			movies = append(movies, m)
+		}
+	}
+	report.Movies = movies
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "html" {
+		err = report.WriteHTML(w)
+	} else {
+		err = report.WriteCSV(w)
+	}
+	if err != nil {
+		return err
+	}
+	slog.Info("Audited overviews", "flagged", len(report.Movies))
+	return nil
+}
+
+func runTypes(ctx context.Context, g *globals, args []string) error {
+	fs := newFlagSet("types")
+	out := fs.String("out", filepath.Join(filepath.Dir(g.utilsDir), "src", "models", "movieData.d.ts"), "TypeScript declaration file to write")
+	check := fs.Bool("check", false, "fail if the declaration file is out of date instead of writing it")
+	fs.Parse(args)
+
+	generated, err := tsgen.Generate(tsgen.Movies)
+	if err != nil {
+		return err
+	}
+	if *check {
+		current, err := os.ReadFile(*out)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(current, generated) {
+			return fmt.Errorf("%s is out of date; run 'talkie types'", *out)
+		}
+		slog.Info("TypeScript declarations are up to date", "file", *out)
+		return nil
+	}
+	if err := os.WriteFile(*out, generated, 0644); err != nil {
+		return err
+	}
+	slog.Info("Wrote TypeScript declarations", "file", *out)
+	return nil
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/unrealities/talkie-trivia/utils/common/credentials"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+	"github.com/unrealities/talkie-trivia/utils/talkie/environment"
+)
+
+// tmdbCredentials resolves the TMDB key or token from flags, the environment
+// or the secrets file.
+func (g *globals) tmdbCredentials() (tmdb.Credentials, error) {
+	secrets, err := credentials.LoadSecrets(g.secrets)
+	if err != nil {
+		return tmdb.Credentials{}, err
+	}
+	creds, source, err := credentials.TMDB(tmdb.Credentials{APIKey: g.tmdbKey, AccessToken: g.tmdbToken}, secrets)
+	if err != nil {
+		return tmdb.Credentials{}, err
+	}
+	kind := "API key"
+	if creds.AccessToken != "" {
+		kind = "access token"
+	}
+	slog.Debug("Using TMDB "+kind, "source", source)
+	return creds, nil
+}
+
+// environment returns the target named by -env, $TALKIE_ENV or the default.
+func (g *globals) environment() (environment.Environment, error) {
+	name := g.env
+	if name == "" {
+		name = os.Getenv(environment.EnvName)
+	}
+	if name == "" {
+		name = environment.Default
+	}
+	cfg, err := environment.Load(filepath.Join(g.utilsDir, environment.FileName))
+	if err != nil {
+		return environment.Environment{}, err
+	}
+	return cfg.Get(name)
+}
+
+// firestoreClient connects to the target environment with credentials from
+// flags, the environment, the secrets file or Application Default
+// Credentials.
+func (g *globals) firestoreClient(ctx context.Context) (*firestore.Client, error) {
+	env, err := g.environment()
+	if err != nil {
+		return nil, err
+	}
+	projectID := g.projectID
+	if projectID == "" {
+		projectID = os.Getenv(environment.EnvProjectID)
+	}
+
+	secrets, err := credentials.LoadSecrets(g.secrets)
+	if err != nil {
+		return nil, err
+	}
+	keyFile, source := credentials.ServiceAccountKey(g.serviceKey, secrets)
+	if env.EmulatorHost == "" {
+		slog.Info("Using Google Cloud credentials", "source", source)
+	}
+	return env.Firestore(ctx, projectID, keyFile)
+}
@@ -0,0 +1,138 @@
+// Package credits aggregates a raw TMDB credits dump into actor and director
+// lists.
+package credits
+
+import (
+	"fmt"
+	"log/slog"
+	"path/filepath"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+)
+
+// Run reads the raw TMDB credits dump from dataDir and writes the popular
+// actor and director lists and the per-movie cast and director maps next to
+// it.
+func Run(dataDir string) error {
+	var movies []tmdb.CreditsResponse
+	if err := datafile.Read(filepath.Join(dataDir, datafile.Credits), &movies); err != nil {
+		return err
+	}
+
+	actors := make(map[int]model.Actor)
+	directors := make(map[int]model.Director)
+	movieActors := make(map[int][]model.MovieActor)
+	movieDirectors := make(map[int]model.MovieDirector)
+	for _, movie := range movies {
+		for _, cast := range movie.Cast {
+			movieOrder := model.MovieOrder{
+				ID:    movie.ID,
+				Order: cast.Order,
+			}
+			actor, exist := actors[cast.ID]
+			if exist {
+				actor.MovieOrders = append(actor.MovieOrders, movieOrder)
+				actor.MovieCount = len(actor.MovieOrders)
+				actors[cast.ID] = actor
+			} else {
+				actors[cast.ID] = model.Actor{
+					ID:          cast.ID,
+					MovieCount:  1,
+					MovieOrders: []model.MovieOrder{movieOrder},
+					Name:        cast.Name,
+					Popularity:  cast.Popularity,
+					ProfilePath: cast.ProfilePath,
+				}
+			}
+		}
+		for _, crew := range movie.Crew {
+			if crew.Job != "Director" {
+				continue
+			}
+			director, exist := directors[crew.ID]
+			if exist {
+				director.MovieCount++
+				directors[crew.ID] = director
+			} else {
+				directors[crew.ID] = model.Director{
+					ID:          crew.ID,
+					MovieCount:  1,
+					Name:        crew.Name,
+					Popularity:  crew.Popularity,
+					ProfilePath: crew.ProfilePath,
+				}
+			}
+		}
+	}
+
+	for _, movie := range movies {
+		for _, cast := range movie.Cast {
+			movieActor := model.MovieActor{
+				ID:          cast.ID,
+				Name:        cast.Name,
+				Character:   cast.Character,
+				Order:       cast.Order,
+				Popularity:  cast.Popularity,
+				ProfilePath: cast.ProfilePath,
+			}
+			ma, exist := movieActors[movie.ID]
+			if exist {
+				ma = append(ma, movieActor)
+				movieActors[movie.ID] = ma
+			} else {
+				movieActors[movie.ID] = []model.MovieActor{movieActor}
+			}
+		}
+		for _, crew := range movie.Crew {
+			if crew.Job != "Director" {
+				continue
+			}
+			movieDirectors[movie.ID] = model.MovieDirector{
+				ID:          crew.ID,
+				Name:        crew.Name,
+				Popularity:  crew.Popularity,
+				ProfilePath: crew.ProfilePath,
+			}
+		}
+	}
+
+	popularActors := make(map[int]model.Actor)
+	for _, a := range actors {
+		if a.Popularity > 9.0 || a.MovieCount > 9 {
+			popularActors[a.ID] = a
+			continue
+		}
+		for _, m := range a.MovieOrders {
+			if m.Order == 0 {
+				popularActors[a.ID] = a
+				continue
+			}
+		}
+	}
+
+	popularDirectors := make(map[int]model.Director)
+	for _, d := range directors {
+		if d.Popularity > 9.0 || d.MovieCount > 9 {
+			popularDirectors[d.ID] = d
+			continue
+		}
+	}
+
+	for _, out := range []struct {
+		name string
+		data interface{}
+	}{
+		{datafile.PopularDirectors, popularDirectors},
+		{datafile.PopularActors, popularActors},
+		{datafile.MovieDirectors, movieDirectors},
+		{datafile.MovieActors, movieActors},
+	} {
+		if err := datafile.Write(filepath.Join(dataDir, out.name), out.data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", out.name, err)
+		}
+	}
+	slog.Info("Aggregated credits", "movies", len(movies), "popularActors", len(popularActors), "popularDirectors", len(popularDirectors))
+	return nil
+}
@@ -0,0 +1,75 @@
+// Package datafile names the JSON files in the data directory and reads and
+// writes them. Writes go through a temp file and rename, so a failed or
+// interrupted write never clobbers a good file.
+package datafile
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Files in the data directory.
+const (
+	PopularMovies    = "popularMovies.json"
+	BasicMovies      = "basicMovies.json"
+	MoviesLite       = "moviesLite.json"
+	Movies           = "movies.json"
+	Credits          = "credits.json"
+	MovieActors      = "movieActors.json"
+	MovieDirectors   = "movieDirectors.json"
+	PopularActors    = "popularActors.json"
+	PopularDirectors = "popularDirectors.json"
+)
+
+// Read decodes the JSON file at path into v.
+func Read(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// Write encodes v as indented JSON and atomically replaces path with it.
+func Write(path string, v interface{}) error {
+	return write(path, v, "  ")
+}
+
+// WriteCompact is Write without indentation, for files bundled into the app.
+func WriteCompact(path string, v interface{}) error {
+	return write(path, v, "")
+}
+
+func write(path string, v interface{}, indent string) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	encoder := json.NewEncoder(tmp)
+	encoder.SetIndent("", indent)
+	if err := encoder.Encode(v); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,309 @@
+// Package diff compares regenerated data files with the versions on disk, so
+// a dry run can show what a rebuild would change before anything is
+// overwritten.
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+)
+
+// DefaultTolerance ignores numeric drift below 10%, since popularity and
+// vote counts move a little on every TMDB refresh.
+const DefaultTolerance = 0.1
+
+// Report collects the differences for every compared file. A nil report
+// compares nothing: its Write writes files as usual.
+type Report struct {
+	// Tolerance is the relative change below which numeric fields count as
+	// unchanged.
+	Tolerance float64    `json:"tolerance"`
+	Files     []FileDiff `json:"files"`
+}
+
+// FileDiff lists the movies added to, removed from and changed in one file.
+type FileDiff struct {
+	File    string        `json:"file"`
+	Added   []MovieRef    `json:"added"`
+	Removed []MovieRef    `json:"removed"`
+	Changed []MovieChange `json:"changed"`
+}
+
+type MovieRef struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+type MovieChange struct {
+	MovieRef
+	Fields []FieldChange `json:"fields"`
+}
+
+// FieldChange is one changed top-level field. Lists of named objects (cast,
+// genres) and named objects (director) are compared by name only, and the
+// names that appeared or disappeared are listed.
+type FieldChange struct {
+	Field   string      `json:"field"`
+	Old     interface{} `json:"old,omitempty"`
+	New     interface{} `json:"new,omitempty"`
+	Added   []string    `json:"added,omitempty"`
+	Removed []string    `json:"removed,omitempty"`
+}
+
+// New returns an empty report.
+func New(tolerance float64) *Report {
+	return &Report{Tolerance: tolerance}
+}
+
+// Write compares v with the file at path and records the differences. On a
+// nil report it writes v to path instead.
+func (r *Report) Write(path string, v interface{}) error {
+	if r == nil {
+		return datafile.Write(path, v)
+	}
+
+	newItems, err := decode(v)
+	if err != nil {
+		return err
+	}
+	var oldItems []map[string]interface{}
+	if err := datafile.Read(path, &oldItems); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	r.Files = append(r.Files, r.compare(filepath.Base(path), oldItems, newItems))
+	return nil
+}
+
+// decode round-trips v through JSON so it is compared exactly as it would
+// be written.
+func decode(v interface{}) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var items []map[string]interface{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func ref(item map[string]interface{}) MovieRef {
+	id, _ := item["id"].(float64)
+	title, _ := item["title"].(string)
+	return MovieRef{ID: int(id), Title: title}
+}
+
+func (r *Report) compare(file string, oldItems, newItems []map[string]interface{}) FileDiff {
+	d := FileDiff{File: file}
+	oldByID := make(map[int]map[string]interface{}, len(oldItems))
+	for _, item := range oldItems {
+		oldByID[ref(item).ID] = item
+	}
+
+	seen := make(map[int]bool, len(newItems))
+	for _, item := range newItems {
+		m := ref(item)
+		seen[m.ID] = true
+		old, ok := oldByID[m.ID]
+		if !ok {
+			d.Added = append(d.Added, m)
+			continue
+		}
+		if fields := r.compareFields(old, item); len(fields) > 0 {
+			d.Changed = append(d.Changed, MovieChange{MovieRef: m, Fields: fields})
+		}
+	}
+	for _, item := range oldItems {
+		if m := ref(item); !seen[m.ID] {
+			d.Removed = append(d.Removed, m)
+		}
+	}
+
+	for _, refs := range [][]MovieRef{d.Added, d.Removed} {
+		sort.Slice(refs, func(i, j int) bool { return refs[i].ID < refs[j].ID })
+	}
+	sort.Slice(d.Changed, func(i, j int) bool { return d.Changed[i].ID < d.Changed[j].ID })
+	return d
+}
+
+func (r *Report) compareFields(before, after map[string]interface{}) []FieldChange {
+	keys := make(map[string]bool)
+	for k := range before {
+		keys[k] = true
+	}
+	for k := range after {
+		keys[k] = true
+	}
+	var fields []string
+	for k := range keys {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+
+	var changes []FieldChange
+	for _, field := range fields {
+		if c, changed := r.compareField(field, before[field], after[field]); changed {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+func (r *Report) compareField(field string, before, after interface{}) (FieldChange, bool) {
+	c := FieldChange{Field: field, Old: before, New: after}
+
+	if oldNum, ok := before.(float64); ok {
+		if newNum, ok := after.(float64); ok {
+			return c, !withinTolerance(oldNum, newNum, r.Tolerance)
+		}
+	}
+
+	oldNames, oldNamed := names(before)
+	newNames, newNamed := names(after)
+	if oldNamed && newNamed {
+		if reflect.DeepEqual(oldNames, newNames) {
+			return c, false
+		}
+		c.Old, c.New = oldNames, newNames
+		c.Added, c.Removed = setDiff(newNames, oldNames), setDiff(oldNames, newNames)
+		return c, true
+	}
+
+	return c, !reflect.DeepEqual(before, after)
+}
+
+func withinTolerance(before, after, tolerance float64) bool {
+	if before == after {
+		return true
+	}
+	if before == 0 {
+		return false
+	}
+	return math.Abs(after-before)/math.Abs(before) < tolerance
+}
+
+// names returns the names of a named object or a list of named objects.
+func names(v interface{}) ([]string, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		name, ok := v["name"].(string)
+		return []string{name}, ok
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			obj, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			name, ok := obj["name"].(string)
+			if !ok {
+				return nil, false
+			}
+			out = append(out, name)
+		}
+		return out, true
+	}
+	return nil, false
+}
+
+// setDiff returns the entries of a missing from b.
+func setDiff(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+	var out []string
+	for _, s := range a {
+		if !inB[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Empty reports whether no file changed.
+func (r *Report) Empty() bool {
+	for _, f := range r.Files {
+		if len(f.Added)+len(f.Removed)+len(f.Changed) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *Report) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r)
+}
+
+// WriteText writes the report in a human-readable form.
+func (r *Report) WriteText(w io.Writer) error {
+	var b strings.Builder
+	for _, f := range r.Files {
+		fmt.Fprintf(&b, "%s: %d added, %d removed, %d changed\n", f.File, len(f.Added), len(f.Removed), len(f.Changed))
+		for _, m := range f.Added {
+			fmt.Fprintf(&b, "  + %d %s\n", m.ID, m.Title)
+		}
+		for _, m := range f.Removed {
+			fmt.Fprintf(&b, "  - %d %s\n", m.ID, m.Title)
+		}
+		for _, m := range f.Changed {
+			fmt.Fprintf(&b, "  ~ %d %s\n", m.ID, m.Title)
+			for _, c := range m.Fields {
+				fmt.Fprintf(&b, "      %s: %s\n", c.Field, c.describe())
+			}
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (c FieldChange) describe() string {
+	if _, named := c.Old.([]string); named {
+		var parts []string
+		for _, name := range c.Removed {
+			parts = append(parts, "-"+name)
+		}
+		for _, name := range c.Added {
+			parts = append(parts, "+"+name)
+		}
+		if len(parts) == 0 {
+			return "reordered"
+		}
+		return strings.Join(parts, ", ")
+	}
+	oldNum, oldOK := c.Old.(float64)
+	newNum, newOK := c.New.(float64)
+	if oldOK && newOK {
+		return fmt.Sprintf("%g -> %g (%+g)", oldNum, newNum, math.Round((newNum-oldNum)*100)/100)
+	}
+	return fmt.Sprintf("%s -> %s", brief(c.Old), brief(c.New))
+}
+
+// brief formats a value for the text report, shortening long strings.
+func brief(v interface{}) string {
+	if v == nil {
+		return "(none)"
+	}
+	if s, ok := v.(string); ok {
+		if r := []rune(s); len(r) > 80 {
+			s = string(r[:77]) + "..."
+		}
+		return fmt.Sprintf("%q", s)
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
@@ -0,0 +1,91 @@
+package diff
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+)
+
+func TestReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "popularMovies.json")
+	old := []model.Movie{
+		{ID: 1, Title: "Alien", Overview: "In space.", Popularity: 100, Actors: []model.MovieActor{{Name: "Sigourney Weaver", Popularity: 30}}},
+		{ID: 2, Title: "Heat", Overview: "In LA.", Popularity: 50, Actors: []model.MovieActor{{Name: "Al Pacino"}}},
+		{ID: 3, Title: "Jaws", Overview: "At sea.", Popularity: 80},
+	}
+	if err := datafile.Write(path, old); err != nil {
+		t.Fatal(err)
+	}
+	before, _ := os.ReadFile(path)
+
+	movies := []model.Movie{
+		// Popularity drifts within tolerance and actor details change, but
+		// nothing a player would notice.
+		{ID: 1, Title: "Alien", Overview: "In space.", Popularity: 105, Actors: []model.MovieActor{{Name: "Sigourney Weaver", Popularity: 35}}},
+		{ID: 2, Title: "Heat", Overview: "In Los Angeles.", Popularity: 80, Actors: []model.MovieActor{{Name: "Robert De Niro"}}},
+		{ID: 4, Title: "Up", Overview: "Balloons."},
+	}
+
+	r := New(DefaultTolerance)
+	if err := r.Write(path, movies); err != nil {
+		t.Fatal(err)
+	}
+	if after, _ := os.ReadFile(path); !bytes.Equal(after, before) {
+		t.Error("Write modified the file on a dry run")
+	}
+
+	if len(r.Files) != 1 {
+		t.Fatalf("got %d file diffs, want 1", len(r.Files))
+	}
+	f := r.Files[0]
+	if want := []MovieRef{{4, "Up"}}; !reflect.DeepEqual(f.Added, want) {
+		t.Errorf("Added = %v, want %v", f.Added, want)
+	}
+	if want := []MovieRef{{3, "Jaws"}}; !reflect.DeepEqual(f.Removed, want) {
+		t.Errorf("Removed = %v, want %v", f.Removed, want)
+	}
+	if len(f.Changed) != 1 || f.Changed[0].ID != 2 {
+		t.Fatalf("Changed = %+v, want only movie 2", f.Changed)
+	}
+	var fields []string
+	for _, c := range f.Changed[0].Fields {
+		fields = append(fields, c.Field)
+	}
+	if want := []string{"actors", "overview", "popularity"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("changed fields = %v, want %v", fields, want)
+	}
+
+	var text bytes.Buffer
+	if err := r.WriteText(&text); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"popularMovies.json: 1 added, 1 removed, 1 changed",
+		"  + 4 Up",
+		"  - 3 Jaws",
+		"      actors: -Al Pacino, +Robert De Niro",
+		`      overview: "In LA." -> "In Los Angeles."`,
+		"      popularity: 50 -> 80 (+30)",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("text report missing %q:\n%s", want, text.String())
+		}
+	}
+}
+
+func TestNilReportWrites(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "basicMovies.json")
+	var r *Report
+	if err := r.Write(path, []model.BasicMovie{{ID: 1, Title: "Alien"}}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("nil report did not write the file: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+// Package environment defines the named deployment targets (dev, staging,
+// prod) the Firestore commands write to. The targets live in
+// utils/environments.json; an environment with an emulator host never
+// reaches a real project.
+package environment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/option"
+)
+
+// FileName is the config file's name in the utils directory.
+const FileName = "environments.json"
+
+// Default is used when neither -env nor $TALKIE_ENV names an environment, so
+// an unqualified run only ever touches the emulator.
+const Default = "dev"
+
+// Environment variables consulted after flags.
+const (
+	EnvName      = "TALKIE_ENV"
+	EnvProjectID = "TALKIE_PROJECT_ID"
+
+	// emulatorHostEnv is read by the Firestore client library itself.
+	emulatorHostEnv = "FIRESTORE_EMULATOR_HOST"
+)
+
+// Environment is one deployment target.
+type Environment struct {
+	Name         string `json:"-"`
+	ProjectID    string `json:"project_id"`
+	EmulatorHost string `json:"emulator_host,omitempty"`
+}
+
+// Config is the on-disk environment configuration.
+type Config struct {
+	Environments map[string]Environment `json:"environments"`
+}
+
+// Load reads an environment config from path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read environment config %s: %w", path, err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not parse environment config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// Get returns the named environment.
+func (c *Config) Get(name string) (Environment, error) {
+	env, ok := c.Environments[name]
+	if !ok {
+		return Environment{}, fmt.Errorf("environment %q not found", name)
+	}
+	env.Name = name
+	return env, nil
+}
+
+// Firestore connects to the environment's project, or its emulator. A
+// non-empty projectID overrides the configured one and keyFile, when set,
+// is a service account key; otherwise Application Default Credentials are
+// used. Credentials are never sent to an emulator.
+func (e Environment) Firestore(ctx context.Context, projectID, keyFile string) (*firestore.Client, error) {
+	if projectID == "" {
+		projectID = e.ProjectID
+	}
+	if projectID == "" {
+		return nil, fmt.Errorf("environment %q has no project ID", e.Name)
+	}
+
+	var opts []option.ClientOption
+	switch host := os.Getenv(emulatorHostEnv); {
+	case e.EmulatorHost != "":
+		if host != "" && host != e.EmulatorHost {
+			return nil, fmt.Errorf("%s=%s conflicts with emulator %s of environment %q", emulatorHostEnv, host, e.EmulatorHost, e.Name)
+		}
+		if err := os.Setenv(emulatorHostEnv, e.EmulatorHost); err != nil {
+			return nil, err
+		}
+		slog.Info("Using the Firestore emulator", "env", e.Name, "host", e.EmulatorHost, "project", projectID)
+	case host != "":
+		return nil, fmt.Errorf("%s is set but environment %q targets the real project %s; unset it or use an emulator environment", emulatorHostEnv, e.Name, projectID)
+	case keyFile != "":
+		opts = append(opts, option.WithCredentialsFile(keyFile))
+	}
+
+	client, err := firestore.NewClient(ctx, projectID, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Firestore client: %w", err)
+	}
+	slog.Info("Connected to Firestore", "env", e.Name, "project", projectID)
+	return client, nil
+}
@@ -1,9 +1,10 @@
-module schedule-games
+module github.com/unrealities/talkie-trivia/utils/talkie
 
 go 1.23.1
 
 require (
 	cloud.google.com/go/firestore v1.18.0
+	golang.org/x/text v0.26.0
 	google.golang.org/api v0.241.0
 )
 
@@ -30,7 +31,6 @@ require (
 	golang.org/x/oauth2 v0.30.0 // indirect
 	golang.org/x/sync v0.15.0 // indirect
 	golang.org/x/sys v0.33.0 // indirect
-	golang.org/x/text v0.26.0 // indirect
 	golang.org/x/time v0.12.0 // indirect
 	google.golang.org/genproto v0.0.0-20250505200425-f936aa4a68b2 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20250505200425-f936aa4a68b2 // indirect
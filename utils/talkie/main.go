@@ -0,0 +1,150 @@
+// Command talkie runs the Talkie Trivia data tools: fetching movies from TMDB,
+// building and validating the bundled data files, and uploading movies and
+// daily game schedules to Firestore.
+//
+// Usage:
+//
+//	talkie [global flags] <command> [command flags]
+//
+// Paths default to the repository checkout containing the working directory,
+// so the tool can be run from anywhere inside it; pass them explicitly
+// elsewhere, e.g. in CI.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/unrealities/talkie-trivia/utils/common/credentials"
+	"github.com/unrealities/talkie-trivia/utils/talkie/environment"
+)
+
+// globals holds the flags shared by every command.
+type globals struct {
+	dataDir  string
+	logLevel string
+
+	// Credentials and deployment target; see credentials.go.
+	secrets    string
+	env        string
+	projectID  string
+	tmdbKey    string
+	tmdbToken  string
+	serviceKey string
+
+	// utilsDir holds filters.json, environments.json, schedule.json,
+	// scheduleRules.json, the secrets file and the .talkie work directory for
+	// caches, checkpoints and run state.
+	utilsDir string
+}
+
+func (g *globals) workPath(name string) string {
+	return filepath.Join(g.utilsDir, ".talkie", name)
+}
+
+type command struct {
+	name    string
+	summary string
+	run     func(ctx context.Context, g *globals, args []string) error
+}
+
+var commands = []command{
+	{"fetch", "discover and fetch movies from TMDB into popularMovies.json and basicMovies.json", runFetch},
+	{"credits", "aggregate a raw credits dump into actor and director lists", runCredits},
+	{"build", "build popularMovies.json and basicMovies.json from raw TMDB dumps", runBuild},
+	{"optimize", "derive moviesLite.json from popularMovies.json", runOptimize},
+	{"populate", "upload popularMovies.json to the Firestore movies collection", runPopulate},
+	{"schedule", "schedule upcoming daily games in Firestore", runSchedule},
+	{"validate", "check the generated data files", runValidate},
+	{"audit", "report words in sanitized overviews that may give the movie away", runAudit},
+	{"types", "generate the app's TypeScript declarations from the Go data model", runTypes},
+}
+
+// repoRoot walks up from the working directory to the checkout containing
+// utils/go.work. It returns "" when there is none.
+func repoRoot() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "utils", "go.work")); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func usage(fs *flag.FlagSet) func() {
+	return func() {
+		out := fs.Output()
+		fmt.Fprintln(out, "Usage: talkie [global flags] <command> [command flags]")
+		fmt.Fprintln(out, "\nCommands:")
+		for _, c := range commands {
+			fmt.Fprintf(out, "  %-10s %s\n", c.name, c.summary)
+		}
+		fmt.Fprintln(out, "\nGlobal flags:")
+		fs.PrintDefaults()
+		fmt.Fprintln(out, "\nRun 'talkie <command> -h' for command flags.")
+	}
+}
+
+func main() {
+	root := repoRoot()
+	dataDir, utilsDir := "data", "."
+	if root != "" {
+		dataDir, utilsDir = filepath.Join(root, "data"), filepath.Join(root, "utils")
+	}
+
+	g := &globals{utilsDir: utilsDir}
+	fs := flag.NewFlagSet("talkie", flag.ExitOnError)
+	fs.StringVar(&g.dataDir, "data-dir", dataDir, "directory holding the generated data files")
+	fs.StringVar(&g.secrets, "secrets", filepath.Join(utilsDir, "secrets.json"), "optional secrets file with TMDB and service account credentials")
+	fs.StringVar(&g.env, "env", "", "target environment from environments.json (default $"+environment.EnvName+" or "+environment.Default+")")
+	fs.StringVar(&g.projectID, "project", "", "Firestore project ID (default $"+environment.EnvProjectID+" or the environment's project)")
+	fs.StringVar(&g.tmdbKey, "tmdb-key", "", "TMDB v3 API key (default $"+credentials.EnvTMDBKey+" or the secrets file)")
+	fs.StringVar(&g.tmdbToken, "tmdb-token", "", "TMDB v4 read access token (default $"+credentials.EnvTMDBToken+" or the secrets file)")
+	fs.StringVar(&g.serviceKey, "credentials", "", "service account key file (default $"+credentials.EnvServiceAccountKey+", the secrets file or Application Default Credentials)")
+	fs.StringVar(&g.logLevel, "log-level", "info", "minimum log level: debug, info, warn or error")
+	fs.Usage = usage(fs)
+	fs.Parse(os.Args[1:])
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(g.logLevel)); err != nil {
+		fmt.Fprintf(os.Stderr, "talkie: invalid -log-level %q\n", g.logLevel)
+		os.Exit(2)
+	}
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})))
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+	name, args := fs.Arg(0), fs.Args()[1:]
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+		if err := c.run(context.Background(), g, args); err != nil {
+			fmt.Fprintf(os.Stderr, "talkie %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var names []string
+	for _, c := range commands {
+		names = append(names, c.name)
+	}
+	fmt.Fprintf(os.Stderr, "talkie: unknown command %q (want one of %s)\n", name, strings.Join(names, ", "))
+	os.Exit(2)
+}
@@ -0,0 +1,70 @@
+// Package optimize derives moviesLite.json, the compact game-logic file
+// bundled with the app, from popularMovies.json.
+package optimize
+
+import (
+	"log/slog"
+	"path/filepath"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+)
+
+// Lite flattens movies into their bundled form.
+func Lite(sourceMovies []model.Movie) []model.LiteMovie {
+	var liteMovies []model.LiteMovie
+
+	for _, m := range sourceMovies {
+		// Flatten Genres
+		genres := []string{}
+		for _, g := range m.Genres {
+			genres = append(genres, g.Name)
+		}
+
+		// Flatten Cast (Top 3 only is usually enough for hints, lets keep 5 to be safe)
+		cast := []string{}
+		limit := 5
+		if len(m.Actors) < limit {
+			limit = len(m.Actors)
+		}
+		for i := 0; i < limit; i++ {
+			cast = append(cast, m.Actors[i].Name)
+		}
+
+		// Extract Year
+		year := ""
+		if len(m.ReleaseDate) >= 4 {
+			year = m.ReleaseDate[:4]
+		}
+
+		liteMovies = append(liteMovies, model.LiteMovie{
+			ID:       m.ID,
+			Director: m.Director.Name,
+			Genres:   genres,
+			Cast:     cast,
+			Year:     year,
+		})
+	}
+	return liteMovies
+}
+
+// Run reads popularMovies.json from dataDir and writes moviesLite.json.
+func Run(dataDir string) error {
+	slog.Info("Reading source file...")
+	var sourceMovies []model.Movie
+	if err := datafile.Read(filepath.Join(dataDir, datafile.PopularMovies), &sourceMovies); err != nil {
+		return err
+	}
+
+	liteMovies := Lite(sourceMovies)
+	slog.Info("Optimized movies", "count", len(liteMovies))
+
+	// No indent to save space
+	outputFile := filepath.Join(dataDir, datafile.MoviesLite)
+	if err := datafile.WriteCompact(outputFile, liteMovies); err != nil {
+		return err
+	}
+
+	slog.Info("Done! Created " + outputFile)
+	return nil
+}
@@ -0,0 +1,226 @@
+package pipeline
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+const (
+	pagesJournalFile  = "pages.jsonl"
+	moviesJournalFile = "movies.jsonl"
+)
+
+// pageEntry records one completed discover page.
+type pageEntry struct {
+	Page int   `json:"page"`
+	IDs  []int `json:"ids"`
+}
+
+// movieEntry records one processed movie. Movie is nil when the movie was
+// fetched successfully but rejected by the quality gates.
+type movieEntry struct {
+	ID    int          `json:"id"`
+	Movie *model.Movie `json:"movie"`
+}
+
+// checkpoint journals completed pages and movies to disk as JSON lines so an
+// interrupted run can pick up where it left off. Only successful units are
+// journaled; failures are retried on the next resumed run. A nil checkpoint
+// journals nothing.
+type checkpoint struct {
+	dir    string
+	mu     sync.Mutex
+	pages  *os.File
+	movies *os.File
+
+	donePages  map[int][]int
+	doneMovies map[int]*model.Movie
+}
+
+// openCheckpoint opens the journal in dir. When resume is false any previous
+// journal is discarded; otherwise its entries are loaded first.
+func openCheckpoint(dir string, resume bool) (*checkpoint, error) {
+	if !resume {
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, fmt.Errorf("could not clear checkpoint directory %s: %w", dir, err)
+		}
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, fmt.Errorf("could not create checkpoint directory %s: %w", dir, err)
+	}
+
+	cp := &checkpoint{
+		dir:        dir,
+		donePages:  make(map[int][]int),
+		doneMovies: make(map[int]*model.Movie),
+	}
+
+	if err := readJournal(filepath.Join(dir, pagesJournalFile), func(line []byte) error {
+		var e pageEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return err
+		}
+		cp.donePages[e.Page] = e.IDs
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if err := readJournal(filepath.Join(dir, moviesJournalFile), func(line []byte) error {
+		var e movieEntry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return err
+		}
+		cp.doneMovies[e.ID] = e.Movie
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if cp.pages, err = openJournal(filepath.Join(dir, pagesJournalFile)); err != nil {
+		return nil, err
+	}
+	if cp.movies, err = openJournal(filepath.Join(dir, moviesJournalFile)); err != nil {
+		cp.pages.Close()
+		return nil, err
+	}
+	return cp, nil
+}
+
+// openJournal opens path for appending, first cutting off any partial final
+// line left by a crash so that new entries start on a line of their own.
+func openJournal(path string) (*os.File, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("could not open journal %s: %w", path, err)
+	}
+	if err := truncatePartialLine(f); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("could not repair journal %s: %w", path, err)
+	}
+	if _, err := f.Seek(0, io.SeekEnd); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("could not open journal %s: %w", path, err)
+	}
+	return f, nil
+}
+
+// truncatePartialLine truncates f just after its last newline.
+func truncatePartialLine(f *os.File) error {
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 || data[len(data)-1] == '\n' {
+		return nil
+	}
+	return f.Truncate(int64(bytes.LastIndexByte(data, '\n') + 1))
+}
+
+// readJournal calls fn for each line of path. A missing file is not an error,
+// and a malformed final line (from a crash mid-write) is ignored.
+func readJournal(path string, fn func(line []byte) error) error {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not open journal %s: %w", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	var pendingErr error
+	for scanner.Scan() {
+		if pendingErr != nil {
+			return fmt.Errorf("corrupt journal %s: %w", path, pendingErr)
+		}
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		pendingErr = fn(scanner.Bytes())
+	}
+	return scanner.Err()
+}
+
+// Page returns the IDs journaled for page, if that page already completed.
+func (c *checkpoint) Page(page int) ([]int, bool) {
+	if c == nil {
+		return nil, false
+	}
+	ids, ok := c.donePages[page]
+	return ids, ok
+}
+
+// Movie returns the journaled result for id, if that movie already completed.
+func (c *checkpoint) Movie(id int) (*model.Movie, bool) {
+	if c == nil {
+		return nil, false
+	}
+	m, ok := c.doneMovies[id]
+	return m, ok
+}
+
+func (c *checkpoint) RecordPage(page int, ids []int) error {
+	if c == nil {
+		return nil
+	}
+	return c.append(c.pages, pageEntry{Page: page, IDs: ids})
+}
+
+func (c *checkpoint) RecordMovie(id int, movie *model.Movie) error {
+	if c == nil {
+		return nil
+	}
+	return c.append(c.movies, movieEntry{ID: id, Movie: movie})
+}
+
+func (c *checkpoint) append(f *os.File, entry interface{}) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err = f.Write(line)
+	return err
+}
+
+// Close closes the journal files. Closing an already closed checkpoint does
+// nothing.
+func (c *checkpoint) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var err error
+	if c.pages != nil {
+		err = c.pages.Close()
+		c.pages = nil
+	}
+	if c.movies != nil {
+		if mErr := c.movies.Close(); err == nil {
+			err = mErr
+		}
+		c.movies = nil
+	}
+	return err
+}
+
+// Remove closes the journal and deletes the checkpoint directory.
+func (c *checkpoint) Remove() error {
+	err := c.Close()
+	if rmErr := os.RemoveAll(c.dir); err == nil {
+		err = rmErr
+	}
+	return err
+}
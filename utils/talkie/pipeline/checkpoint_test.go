@@ -0,0 +1,134 @@
+package pipeline
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	dir := t.TempDir()
+	cp, err := openCheckpoint(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.RecordPage(1, []int{10, 20}); err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.RecordMovie(10, &model.Movie{ID: 10, Title: "Alien"}); err != nil {
+		t.Fatal(err)
+	}
+	// A movie rejected by the gates is journaled as nil.
+	if err := cp.RecordMovie(20, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err = openCheckpoint(dir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cp.Close()
+	if ids, ok := cp.Page(1); !ok || !reflect.DeepEqual(ids, []int{10, 20}) {
+		t.Errorf("Page(1) = %v, %v, want [10 20], true", ids, ok)
+	}
+	if _, ok := cp.Page(2); ok {
+		t.Error("Page(2) reported done")
+	}
+	if m, ok := cp.Movie(10); !ok || m == nil || m.Title != "Alien" {
+		t.Errorf("Movie(10) = %v, %v, want Alien", m, ok)
+	}
+	if m, ok := cp.Movie(20); !ok || m != nil {
+		t.Errorf("Movie(20) = %v, %v, want nil, true", m, ok)
+	}
+}
+
+func TestCheckpointDiscardsWithoutResume(t *testing.T) {
+	dir := t.TempDir()
+	cp, err := openCheckpoint(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.RecordPage(1, []int{10}); err != nil {
+		t.Fatal(err)
+	}
+	cp.Close()
+
+	cp, err = openCheckpoint(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cp.Close()
+	if _, ok := cp.Page(1); ok {
+		t.Error("Page(1) survived a fresh run")
+	}
+}
+
+func TestCheckpointPartialLine(t *testing.T) {
+	dir := t.TempDir()
+	cp, err := openCheckpoint(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.RecordMovie(10, &model.Movie{ID: 10, Title: "Alien"}); err != nil {
+		t.Fatal(err)
+	}
+	cp.Close()
+
+	// Simulate a crash part way through writing the next entry.
+	path := filepath.Join(dir, moviesJournalFile)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`{"id":20,"movie":{"ti`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	cp, err = openCheckpoint(dir, true)
+	if err != nil {
+		t.Fatalf("first resume: %v", err)
+	}
+	if _, ok := cp.Movie(20); ok {
+		t.Error("partial entry for movie 20 was loaded")
+	}
+	if err := cp.RecordMovie(30, &model.Movie{ID: 30, Title: "Heat"}); err != nil {
+		t.Fatal(err)
+	}
+	cp.Close()
+
+	cp, err = openCheckpoint(dir, true)
+	if err != nil {
+		t.Fatalf("second resume: %v", err)
+	}
+	defer cp.Close()
+	for id, title := range map[int]string{10: "Alien", 30: "Heat"} {
+		if m, ok := cp.Movie(id); !ok || m == nil || m.Title != title {
+			t.Errorf("Movie(%d) = %v, %v, want %s", id, m, ok, title)
+		}
+	}
+}
+
+func TestCheckpointRemove(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "checkpoint")
+	cp, err := openCheckpoint(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.Remove(); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("checkpoint directory still exists: %v", err)
+	}
+	// Run closes the checkpoint again on its way out.
+	if err := cp.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// failureLog collects IDs that could not be fetched after all retries so the
+// run can report them instead of silently dropping movies.
+type failureLog struct {
+	mu     sync.Mutex
+	pages  map[int]error
+	movies map[int]error
+}
+
+func newFailureLog() *failureLog {
+	return &failureLog{pages: make(map[int]error), movies: make(map[int]error)}
+}
+
+func (l *failureLog) addPage(page int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.pages[page] = err
+}
+
+func (l *failureLog) addMovie(id int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.movies[id] = err
+}
+
+// Lines returns one human-readable line per failure, pages first, sorted by ID.
+func (l *failureLog) Lines() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var lines []string
+	for _, page := range sortedKeys(l.pages) {
+		lines = append(lines, fmt.Sprintf("discover page %d: %v", page, l.pages[page]))
+	}
+	for _, id := range sortedKeys(l.movies) {
+		lines = append(lines, fmt.Sprintf("movie %d: %v", id, l.movies[id]))
+	}
+	return lines
+}
+
+func sortedKeys(m map[int]error) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
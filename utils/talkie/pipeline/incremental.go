@@ -0,0 +1,247 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/filters"
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+	"github.com/unrealities/talkie-trivia/utils/talkie/sanitize"
+)
+
+// TMDB rejects /movie/changes queries spanning more than 14 days.
+const changesWindow = 14 * 24 * time.Hour
+
+// runState is persisted after every clean run so incremental refreshes know
+// which point in the changes feed to resume from.
+type runState struct {
+	LastRun time.Time `json:"last_run"`
+}
+
+func loadRunState(path string) (runState, error) {
+	var state runState
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, fmt.Errorf("no previous run recorded in %s; run a full build first", path)
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("could not parse %s: %w", path, err)
+	}
+	if state.LastRun.IsZero() {
+		return state, fmt.Errorf("%s has no last_run timestamp", path)
+	}
+	return state, nil
+}
+
+func saveRunState(path string, state runState) error {
+	return datafile.Write(path, state)
+}
+
+// loadMovies reads a previous popularMovies.json. Older files may hold null
+// for a movie without cast or genres; those become empty lists, as the data
+// file schemas require.
+func loadMovies(path string) ([]model.Movie, error) {
+	var movies []model.Movie
+	if err := datafile.Read(path, &movies); err != nil {
+		return nil, err
+	}
+	for i := range movies {
+		if movies[i].Actors == nil {
+			movies[i].Actors = []model.MovieActor{}
+		}
+		if movies[i].Genres == nil {
+			movies[i].Genres = []model.Genre{}
+		}
+	}
+	return movies, nil
+}
+
+// fetchChangedIDs walks the /movie/changes feed from since until now in
+// windows TMDB accepts and returns every movie ID that changed.
+func fetchChangedIDs(ctx context.Context, f *tmdb.Client, since, now time.Time) (map[int]bool, error) {
+	changed := make(map[int]bool)
+	for start := since; start.Before(now); start = start.Add(changesWindow) {
+		end := start.Add(changesWindow)
+		if end.After(now) {
+			end = now
+		}
+		for page, totalPages := 1, 1; page <= totalPages; page++ {
+			var resp tmdb.ChangesResponse
+			if err := f.GetJSON(ctx, "/movie/changes", map[string]string{
+				"start_date": start.Format("2006-01-02"),
+				"end_date":   end.Format("2006-01-02"),
+				"page":       strconv.Itoa(page),
+			}, &resp); err != nil {
+				return nil, fmt.Errorf("failed to fetch changes page %d from %s: %w", page, start.Format("2006-01-02"), err)
+			}
+			for _, r := range resp.Results {
+				changed[r.ID] = true
+			}
+			totalPages = resp.TotalPages
+		}
+	}
+	return changed, nil
+}
+
+type summaryEntry struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// changeSummary describes how an incremental refresh altered the dataset.
+type changeSummary struct {
+	Added   []summaryEntry `json:"added"`
+	Updated []summaryEntry `json:"updated"`
+	Dropped []summaryEntry `json:"dropped"`
+}
+
+func (s changeSummary) Log() {
+	slog.Info("Incremental refresh", "added", len(s.Added), "updated", len(s.Updated), "dropped", len(s.Dropped))
+	for _, group := range []struct {
+		label   string
+		entries []summaryEntry
+	}{{"+", s.Added}, {"~", s.Updated}, {"-", s.Dropped}} {
+		for _, e := range group.entries {
+			slog.Info(fmt.Sprintf("  %s %d %s", group.label, e.ID, e.Title))
+		}
+	}
+}
+
+// runIncremental refreshes existing using the TMDB changes feed. Existing
+// movies that changed are re-fetched, bypassing cache freshness, and either
+// updated or dropped if they no longer pass the quality gates. Newly
+// discovered movies are fetched normally; the discover pages go through the
+// cache like a full build, so new releases show up once their page expires.
+// Unchanged movies are re-checked against the gates on their stored fields,
+// which lack a runtime, and re-sanitized so filter and sanitizer changes
+// apply to the whole dataset.
+func runIncremental(ctx context.Context, f *tmdb.Client, gate *filters.Gate, since time.Time, existing []model.Movie, workers int, failures *failureLog) ([]model.Movie, changeSummary, error) {
+	var summary changeSummary
+
+	fresh := *f
+	fresh.Revalidate = true
+
+	slog.Info("Fetching TMDB changes", "since", since.Format(time.RFC3339))
+	changed, err := fetchChangedIDs(ctx, &fresh, since, time.Now().UTC())
+	if err != nil {
+		return nil, summary, err
+	}
+	slog.Info("TMDB reports changed movies", "count", len(changed))
+
+	existingByID := make(map[int]int, len(existing))
+	var ids []int
+	for i, m := range existing {
+		existingByID[m.ID] = i
+		ids = append(ids, m.ID)
+	}
+
+	slog.Info("Fetching movie IDs from TMDB discover endpoint...")
+	for _, id := range discoverMovieIDs(ctx, f, gate, nil, workers, failures) {
+		if _, ok := existingByID[id]; !ok {
+			ids = append(ids, id)
+		}
+	}
+
+	type outcome struct {
+		movie   *model.Movie
+		dropped bool
+	}
+	// keep copies an existing movie, actors included, so that filling in
+	// people's IMDb IDs below doesn't alter existing.
+	keep := func(idx int) *model.Movie {
+		m := existing[idx]
+		if m.Actors != nil {
+			m.Actors = append(make([]model.MovieActor, 0, len(m.Actors)), m.Actors...)
+		}
+		return &m
+	}
+	results := make([]outcome, len(ids))
+	runPool(ctx, len(ids), workers, func(ctx context.Context, i int) {
+		id := ids[i]
+		idx, isExisting := existingByID[id]
+		if isExisting && !changed[id] {
+			m := keep(idx)
+			if !allowStored(gate, m) {
+				results[i] = outcome{dropped: true}
+				return
+			}
+			// Movies written before taglines were sanitized only have the
+			// original, in Tagline.
+			if m.OriginalTagline == "" {
+				m.OriginalTagline = m.Tagline
+			}
+			sanitize.Movie(m)
+			results[i] = outcome{movie: m}
+			return
+		}
+
+		mf := f
+		if changed[id] {
+			mf = &fresh
+		}
+		slog.Debug("Processing movie", "id", id)
+		movie, err := fetchMovie(ctx, mf, gate, id)
+		var apiErr *tmdb.APIError
+		switch {
+		case err != nil && isExisting && errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound:
+			results[i] = outcome{dropped: true}
+		case err != nil:
+			slog.Warn(err.Error())
+			failures.addMovie(id, err)
+			if isExisting {
+				results[i] = outcome{movie: keep(idx)}
+			}
+		default:
+			results[i] = outcome{movie: movie, dropped: movie == nil}
+		}
+	})
+
+	movies := make([]*model.Movie, len(results))
+	for i, r := range results {
+		movies[i] = r.movie
+	}
+	addPersonImdbIDs(ctx, f, movies, workers)
+
+	var merged []model.Movie
+	for i, r := range results {
+		idx, isExisting := existingByID[ids[i]]
+		switch {
+		case r.dropped && isExisting:
+			summary.Dropped = append(summary.Dropped, summaryEntry{ID: ids[i], Title: existing[idx].Title})
+		case r.movie == nil:
+		case !isExisting:
+			summary.Added = append(summary.Added, summaryEntry{ID: r.movie.ID, Title: r.movie.Title})
+		case !sameMovie(*r.movie, existing[idx]):
+			summary.Updated = append(summary.Updated, summaryEntry{ID: r.movie.ID, Title: r.movie.Title})
+		}
+		if r.movie != nil {
+			merged = append(merged, *r.movie)
+		}
+	}
+	return merged, summary, nil
+}
+
+// sameMovie compares movies by their serialized form, which is exactly what
+// ends up in popularMovies.json. Overviews and Clues are ignored: they are
+// derived from OriginalOverview across the whole dataset only when the
+// outputs are written, so re-fetched movies don't have them yet.
+func sameMovie(a, b model.Movie) bool {
+	a.Overviews, a.Clues = nil, nil
+	b.Overviews, b.Clues = nil, nil
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(aj, bj)
+}
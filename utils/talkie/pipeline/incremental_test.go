@@ -0,0 +1,149 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/filters"
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+)
+
+func TestSameMovieIgnoresDerivedFields(t *testing.T) {
+	stored := model.Movie{
+		ID:               1,
+		Title:            "Alien",
+		OriginalOverview: "The crew of a spaceship answers a distress call.",
+		Overview:         "The crew of a spaceship answers a distress call.",
+		Overviews:        map[string]string{"easy": "The crew of a spaceship answers a distress call."},
+		Clues:            []string{"The crew of a spaceship", "answers a distress call."},
+	}
+	refetched := stored
+	refetched.Overviews, refetched.Clues = nil, nil
+	if !sameMovie(refetched, stored) {
+		t.Error("sameMovie reported a change in derived fields only")
+	}
+
+	refetched.Overview = "A crew answers a distress call."
+	if sameMovie(refetched, stored) {
+		t.Error("sameMovie missed an overview change")
+	}
+}
+
+func TestRunIncremental(t *testing.T) {
+	details := map[int]tmdb.DetailsResponse{
+		3: {ID: 3, Title: "Jaws", Overview: "A shark terrorizes a beach town.", Runtime: 124, VoteCount: 500},
+		4: {ID: 4, Title: "Up", Overview: "An old man ties balloons to his house and flies away.", Runtime: 96, VoteCount: 500},
+		6: {ID: 6, Title: "Heat", Overview: "A detective hunts a crew of thieves.", Runtime: 170, VoteCount: 500},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/movie/changes":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"results":     []map[string]int{{"id": 3}, {"id": 4}, {"id": 5}},
+				"total_pages": 1,
+			})
+		case r.URL.Path == "/discover/movie":
+			var results []map[string]int
+			if r.URL.Query().Get("page") == "1" {
+				results = []map[string]int{{"id": 1}, {"id": 6}}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		case strings.HasPrefix(r.URL.Path, "/movie/"):
+			for id, d := range details {
+				if r.URL.Path == "/movie/"+strconv.Itoa(id) {
+					json.NewEncoder(w).Encode(d)
+					return
+				}
+			}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	f := tmdb.NewClient(tmdb.Credentials{APIKey: "test-key"})
+	f.BaseURL = srv.URL
+	ctx := context.Background()
+
+	runtime, votes := 75, 100
+	gate := testGate(t, filters.Profile{RuntimeGT: &runtime, VoteCountGT: &votes})
+
+	// Jaws is stored exactly as TMDB still returns it, plus the derived
+	// fields that only the output step adds.
+	jaws, err := fetchMovie(ctx, f, gate, 3)
+	if err != nil || jaws == nil {
+		t.Fatalf("fetchMovie(3) = %v, %v", jaws, err)
+	}
+	jaws.Overviews = map[string]string{"easy": jaws.Overview}
+	jaws.Clues = []string{jaws.Overview}
+
+	existing := []model.Movie{
+		// Unchanged and still passing.
+		{ID: 1, Title: "Alien", OriginalOverview: "The crew of a spaceship answers a distress call.", Overview: "The crew of a spaceship answers a distress call.", VoteCount: 500},
+		// Unchanged, but no longer passes the vote count rule.
+		{ID: 2, Title: "Rocky", OriginalOverview: "A boxer gets a shot at the title.", VoteCount: 50},
+		*jaws,
+		// Changed overview.
+		{ID: 4, Title: "Up", OriginalOverview: "An old man flies away.", Overview: "An old man flies away.", VoteCount: 500},
+		// Removed from TMDB.
+		{ID: 5, Title: "Big", OriginalOverview: "A boy wakes up as an adult.", VoteCount: 500},
+	}
+
+	failures := newFailureLog()
+	merged, summary, err := runIncremental(ctx, f, gate, time.Now().Add(-24*time.Hour), existing, 4, failures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := failures.Lines(); len(lines) != 0 {
+		t.Errorf("unexpected failures: %v", lines)
+	}
+
+	var ids []int
+	for _, m := range merged {
+		ids = append(ids, m.ID)
+	}
+	if want := []int{1, 3, 4, 6}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("merged IDs = %v, want %v", ids, want)
+	}
+	if want := []summaryEntry{{6, "Heat"}}; !reflect.DeepEqual(summary.Added, want) {
+		t.Errorf("Added = %v, want %v", summary.Added, want)
+	}
+	if want := []summaryEntry{{4, "Up"}}; !reflect.DeepEqual(summary.Updated, want) {
+		t.Errorf("Updated = %v, want %v", summary.Updated, want)
+	}
+	if want := []summaryEntry{{2, "Rocky"}, {5, "Big"}}; !reflect.DeepEqual(summary.Dropped, want) {
+		t.Errorf("Dropped = %v, want %v", summary.Dropped, want)
+	}
+}
+
+func TestLoadMoviesEmptyLists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), datafile.PopularMovies)
+	if err := os.WriteFile(path, []byte(`[{"id":1,"title":"Alien","actors":null,"genres":null}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	movies, err := loadMovies(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(movies[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"actors":[]`, `"genres":[]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("%s does not contain %s", data, want)
+		}
+	}
+}
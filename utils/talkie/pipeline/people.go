@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+)
+
+// addPersonImdbIDs fills in the IMDb IDs of each movie's director and
+// billed cast so the app can link to them. IDs already known from any movie
+// are reused, and every other person is looked up once however many movies
+// they appear in. A failed lookup only leaves the ID empty, to be retried on
+// the next run. Nil entries are skipped.
+func addPersonImdbIDs(ctx context.Context, f *tmdb.Client, movies []*model.Movie, workers int) {
+	known := make(map[int]string)
+	for _, m := range movies {
+		if m == nil {
+			continue
+		}
+		if m.Director.ImdbID != "" {
+			known[m.Director.ID] = m.Director.ImdbID
+		}
+		for _, a := range m.Actors {
+			if a.ImdbID != "" {
+				known[a.ID] = a.ImdbID
+			}
+		}
+	}
+
+	var ids []int
+	queued := make(map[int]bool)
+	queue := func(id int) {
+		if _, ok := known[id]; !ok && id != 0 && !queued[id] {
+			queued[id] = true
+			ids = append(ids, id)
+		}
+	}
+	for _, m := range movies {
+		if m == nil {
+			continue
+		}
+		queue(m.Director.ID)
+		for _, a := range m.Actors {
+			queue(a.ID)
+		}
+	}
+
+	slog.Info("Fetching IMDb IDs for people", "count", len(ids))
+	found := make([]string, len(ids))
+	runPool(ctx, len(ids), workers, func(ctx context.Context, i int) {
+		var resp tmdb.ExternalIDs
+		if err := f.GetJSON(ctx, fmt.Sprintf("/person/%d/external_ids", ids[i]), nil, &resp); err != nil {
+			slog.Warn("Could not fetch person IMDb ID", "id", ids[i], "err", err)
+			return
+		}
+		found[i] = resp.ImdbID
+	})
+	for i, id := range ids {
+		known[id] = found[i]
+	}
+
+	for _, m := range movies {
+		if m == nil {
+			continue
+		}
+		m.Director.ImdbID = known[m.Director.ID]
+		for i := range m.Actors {
+			m.Actors[i].ImdbID = known[m.Actors[i].ID]
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package pipeline
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+)
+
+func TestAddPersonImdbIDs(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/person/10/external_ids":
+			w.Write([]byte(`{"imdb_id":"nm0000129"}`))
+		case "/person/20/external_ids":
+			w.Write([]byte(`{"imdb_id":"nm0001467"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	f := tmdb.NewClient(tmdb.Credentials{APIKey: "test-key"})
+	f.BaseURL = srv.URL
+
+	movies := []*model.Movie{
+		{
+			ID:       1,
+			Director: model.MovieDirector{ID: 20},
+			Actors:   []model.MovieActor{{ID: 10}, {ID: 30, ImdbID: "nm0000102"}},
+		},
+		nil,
+		{
+			ID:       2,
+			Director: model.MovieDirector{ID: 40},
+			Actors:   []model.MovieActor{{ID: 10}, {ID: 30}},
+		},
+	}
+	addPersonImdbIDs(context.Background(), f, movies, 2)
+
+	if got := movies[0].Director.ImdbID; got != "nm0001467" {
+		t.Errorf("director 20 = %q, want nm0001467", got)
+	}
+	for _, m := range []*model.Movie{movies[0], movies[2]} {
+		if got := m.Actors[0].ImdbID; got != "nm0000129" {
+			t.Errorf("movie %d actor 10 = %q, want nm0000129", m.ID, got)
+		}
+		if got := m.Actors[1].ImdbID; got != "nm0000102" {
+			t.Errorf("movie %d actor 30 = %q, want the known nm0000102", m.ID, got)
+		}
+	}
+	if got := movies[2].Director.ImdbID; got != "" {
+		t.Errorf("director 40 = %q, want empty after a failed lookup", got)
+	}
+
+	want := map[string]int{"/person/10/external_ids": 1, "/person/20/external_ids": 1, "/person/40/external_ids": 1}
+	if len(requests) != len(want) {
+		t.Errorf("requests = %v, want %v", requests, want)
+	}
+	for path, n := range want {
+		if requests[path] != n {
+			t.Errorf("%s requested %d times, want %d", path, requests[path], n)
+		}
+	}
+}
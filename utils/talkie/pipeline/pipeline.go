@@ -0,0 +1,424 @@
+// Package pipeline discovers popular movies on TMDB, fetches their details and
+// credits, applies the quality gates and writes popularMovies.json and
+// basicMovies.json to the data directory.
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/filters"
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+	"github.com/unrealities/talkie-trivia/utils/talkie/diff"
+	"github.com/unrealities/talkie-trivia/utils/talkie/sanitize"
+)
+
+const (
+	pagesToFetch = 500
+
+	certificationCountry = "US"
+)
+
+// alternativeTitleCountries are the markets whose alternative titles are
+// redacted from overviews; titles elsewhere are rarely in English.
+var alternativeTitleCountries = []string{"US", "GB", "CA", "AU", "NZ", "IE"}
+
+// fetchDiscoverPage returns the movie IDs listed on one discover page.
+// The runtime and vote count bounds of the filter profile are pushed down
+// into the query so TMDB does the coarse filtering for us.
+func fetchDiscoverPage(ctx context.Context, f *tmdb.Client, profile filters.Profile, page int) ([]int, error) {
+	discoverParams := map[string]string{
+		"page":          strconv.Itoa(page),
+		"include_adult": "false",
+		"include_video": "false",
+		"language":      "en-US",
+		"sort_by":       "popularity.desc",
+	}
+	if profile.RuntimeGT != nil {
+		discoverParams["with_runtime.gte"] = strconv.Itoa(*profile.RuntimeGT)
+	}
+	if profile.VoteCountGT != nil {
+		discoverParams["vote_count.gte"] = strconv.Itoa(*profile.VoteCountGT)
+	}
+
+	var discoverResp tmdb.DiscoverResponse
+	if err := f.GetJSON(ctx, "/discover/movie", discoverParams, &discoverResp); err != nil {
+		return nil, fmt.Errorf("failed to fetch page %d: %w", page, err)
+	}
+
+	ids := make([]int, 0, len(discoverResp.Results))
+	for _, movieStub := range discoverResp.Results {
+		ids = append(ids, movieStub.ID)
+	}
+	return ids, nil
+}
+
+// fetchMovie fetches one movie with all appended sub-resources in a single
+// request and builds the final record. It returns nil without error when the
+// movie fails the quality gates.
+func fetchMovie(ctx context.Context, f *tmdb.Client, gate *filters.Gate, movieID int) (*model.Movie, error) {
+	var resp tmdb.MovieResponse
+	if err := f.GetJSON(ctx, fmt.Sprintf("/movie/%d", movieID), map[string]string{
+		"append_to_response": tmdb.MovieAppendToResponse,
+	}, &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch movie %d: %w", movieID, err)
+	}
+	details, credits := resp.DetailsResponse, resp.Credits
+
+	if !gate.Allow(filters.Candidate{
+		Overview:    details.Overview,
+		Runtime:     details.Runtime,
+		Popularity:  details.Popularity,
+		VoteAverage: details.VoteAverage,
+		VoteCount:   details.VoteCount,
+	}) {
+		return nil, nil
+	}
+
+	var director model.MovieDirector
+	if crew, ok := credits.Director(); ok {
+		director = model.MovieDirector{
+			ID:          crew.ID,
+			Name:        crew.Name,
+			Popularity:  crew.Popularity,
+			ProfilePath: crew.ProfilePath,
+		}
+	}
+
+	actors := []model.MovieActor{}
+	sort.Slice(credits.Cast, func(i, j int) bool {
+		return credits.Cast[i].Order < credits.Cast[j].Order
+	})
+
+	for _, castMember := range credits.Cast {
+		if castMember.Order < 5 {
+			actors = append(actors, model.MovieActor{
+				ID:          castMember.ID,
+				Order:       castMember.Order,
+				Name:        castMember.Name,
+				Character:   castMember.Character,
+				Popularity:  castMember.Popularity,
+				ProfilePath: castMember.ProfilePath,
+			})
+		}
+	}
+
+	imdbID := details.ImdbID
+	if imdbID == "" {
+		imdbID = resp.ExternalIDs.ImdbID
+	}
+
+	genres := details.Genres
+	if genres == nil {
+		genres = []model.Genre{}
+	}
+
+	m := &model.Movie{
+		Actors:           actors,
+		Director:         director,
+		Genres:           genres,
+		ID:               details.ID,
+		ImdbID:           imdbID,
+		Keywords:         resp.Keywords.Names(),
+		Certification:    resp.ReleaseDates.Certification(certificationCountry),
+		Collection:       details.BelongsToCollection.Model(),
+		OriginalOverview: details.Overview,
+		Popularity:       details.Popularity,
+		PosterPath:       details.PosterPath,
+		ReleaseDate:      details.ReleaseDate,
+		OriginalTagline:  details.Tagline,
+		Title:            details.Title,
+		AltTitles:        resp.AlternativeTitles.Names(details.Title, alternativeTitleCountries...),
+		VoteAverage:      details.VoteAverage,
+		VoteCount:        details.VoteCount,
+	}
+	sanitize.Movie(m)
+	return m, nil
+}
+
+// allowStored re-checks a movie kept from an earlier run against gate.
+// Stored records have no runtime, so that rule is skipped.
+func allowStored(gate *filters.Gate, m *model.Movie) bool {
+	return gate.AllowKnown(filters.Candidate{
+		Overview:    m.OriginalOverview,
+		Popularity:  m.Popularity,
+		VoteAverage: m.VoteAverage,
+		VoteCount:   m.VoteCount,
+	}, filters.RuleRuntime)
+}
+
+// discoverMovieIDs fetches every discover page and returns the unique movie
+// IDs in page order, so the movie list is stable across runs.
+func discoverMovieIDs(ctx context.Context, f *tmdb.Client, gate *filters.Gate, cp *checkpoint, workers int, failures *failureLog) []int {
+	pages := make([][]int, pagesToFetch)
+	runPool(ctx, pagesToFetch, workers, func(ctx context.Context, i int) {
+		page := i + 1
+		if ids, ok := cp.Page(page); ok {
+			pages[i] = ids
+			return
+		}
+		slog.Debug("Fetching discover page", "page", page, "of", pagesToFetch)
+		ids, err := fetchDiscoverPage(ctx, f, gate.Profile, page)
+		if err != nil {
+			slog.Warn(err.Error())
+			failures.addPage(page, err)
+			return
+		}
+		pages[i] = ids
+		if err := cp.RecordPage(page, ids); err != nil {
+			slog.Warn("Could not journal page", "page", page, "err", err)
+		}
+	})
+
+	var movieIDs []int
+	seenIDs := make(map[int]bool)
+	for _, ids := range pages {
+		for _, id := range ids {
+			if seenIDs[id] {
+				continue
+			}
+			seenIDs[id] = true
+			movieIDs = append(movieIDs, id)
+		}
+	}
+	return movieIDs
+}
+
+// fetchMovies fetches every ID concurrently and returns results in the same
+// order as ids. Entries are nil for movies that failed or were filtered out.
+func fetchMovies(ctx context.Context, f *tmdb.Client, gate *filters.Gate, cp *checkpoint, ids []int, workers int, failures *failureLog) []*model.Movie {
+	results := make([]*model.Movie, len(ids))
+	runPool(ctx, len(ids), workers, func(ctx context.Context, i int) {
+		if movie, ok := cp.Movie(ids[i]); ok {
+			// The filters may have changed since the movie was journaled.
+			if movie != nil && !allowStored(gate, movie) {
+				movie = nil
+			}
+			results[i] = movie
+			return
+		}
+		slog.Debug("Processing movie", "id", ids[i])
+		movie, err := fetchMovie(ctx, f, gate, ids[i])
+		if err != nil {
+			slog.Warn(err.Error())
+			failures.addMovie(ids[i], err)
+			return
+		}
+		results[i] = movie
+		if err := cp.RecordMovie(ids[i], movie); err != nil {
+			slog.Warn("Could not journal movie", "id", ids[i], "err", err)
+		}
+	})
+	return results
+}
+
+// BuildBasicMovies derives the search index, disambiguating duplicate titles
+// with their release year and sorting by title.
+func BuildBasicMovies(movies []model.Movie) []model.BasicMovie {
+	basicMovies := make([]model.BasicMovie, 0, len(movies))
+	titleCounts := make(map[string]int)
+	for _, movie := range movies {
+		basicMovies = append(basicMovies, model.BasicMovie{
+			ID:          movie.ID,
+			Title:       movie.Title,
+			ReleaseDate: movie.ReleaseDate,
+			PosterPath:  movie.PosterPath,
+		})
+		titleCounts[movie.Title]++
+	}
+
+	for i, m := range basicMovies {
+		if titleCounts[m.Title] > 1 {
+			year := strings.Split(m.ReleaseDate, "-")[0]
+			basicMovies[i].Title = fmt.Sprintf("%s (%s)", m.Title, year)
+		}
+	}
+
+	sort.SliceStable(basicMovies, func(i, j int) bool {
+		return basicMovies[i].Title < basicMovies[j].Title
+	})
+	return basicMovies
+}
+
+// writeOutputs adds the tiered overview variants and writes
+// popularMovies.json and basicMovies.json, or only compares them with the
+// files on disk when report is non-nil.
+func writeOutputs(dataDir string, movies []model.Movie, report *diff.Report) error {
+	if report == nil {
+		slog.Info("Writing output files", "dir", dataDir)
+	} else {
+		slog.Info("Comparing output files", "dir", dataDir)
+	}
+
+	sanitize.AddOverviews(movies)
+	LogUnsafeTaglines(movies)
+	popularMoviesPath := filepath.Join(dataDir, datafile.PopularMovies)
+	if err := report.Write(popularMoviesPath, movies); err != nil {
+		return fmt.Errorf("failed to write %s: %w", datafile.PopularMovies, err)
+	}
+
+	slog.Info("De-duplicating titles and sorting basic movies list...")
+	basicMoviesPath := filepath.Join(dataDir, datafile.BasicMovies)
+	if err := report.Write(basicMoviesPath, BuildBasicMovies(movies)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", datafile.BasicMovies, err)
+	}
+	return nil
+}
+
+// LogUnsafeTaglines reports the movies whose tagline was withheld because
+// it could not be redacted without giving the movie away.
+func LogUnsafeTaglines(movies []model.Movie) {
+	unsafe := 0
+	for _, m := range movies {
+		if m.TaglineUnsafe {
+			slog.Debug("Withholding tagline", "id", m.ID, "title", m.Title, "tagline", m.OriginalTagline)
+			unsafe++
+		}
+	}
+	slog.Info("Sanitized taglines", "withheld", unsafe, "movies", len(movies))
+}
+
+// Options configures a pipeline run.
+type Options struct {
+	Client  *tmdb.Client
+	Gate    *filters.Gate
+	DataDir string
+	Workers int
+
+	// CheckpointDir holds the resumable run journal. Resume continues from
+	// it instead of starting over.
+	CheckpointDir string
+	Resume        bool
+
+	// Incremental refreshes only movies changed on TMDB since the run
+	// recorded in StateFile. A clean run records its start time there unless
+	// StateFile is empty.
+	Incremental bool
+	StateFile   string
+
+	// Diff, when set, makes the run a dry run: outputs are compared with the
+	// files on disk into Diff instead of being written, and no run state is
+	// recorded.
+	Diff *diff.Report
+}
+
+// Run executes a full or incremental pipeline run. Units that still fail
+// after all retries are reported but do not fail the run. A full run with
+// failures leaves the output files as they were and keeps its checkpoint so
+// the failed units can be retried with Resume. An incremental run writes
+// anyway: a movie that failed keeps its existing record, so the dataset is
+// never partial.
+func Run(ctx context.Context, opts Options) error {
+	f, gate := opts.Client, opts.Gate
+	failures := newFailureLog()
+	runStarted := time.Now().UTC()
+	stateFile := opts.StateFile
+	if opts.Diff != nil {
+		stateFile = ""
+	}
+
+	if opts.Incremental {
+		state, err := loadRunState(opts.StateFile)
+		if err != nil {
+			return fmt.Errorf("error loading run state: %w", err)
+		}
+		existing, err := loadMovies(filepath.Join(opts.DataDir, datafile.PopularMovies))
+		if err != nil {
+			return fmt.Errorf("error loading existing movies: %w", err)
+		}
+
+		merged, summary, err := runIncremental(ctx, f, gate, state.LastRun, existing, opts.Workers, failures)
+		if err != nil {
+			return fmt.Errorf("incremental refresh failed: %w", err)
+		}
+		summary.Log()
+		logLines(gate.Report())
+		if err := writeOutputs(opts.DataDir, merged, opts.Diff); err != nil {
+			return err
+		}
+		if reportFailures(failures) {
+			finishRun(stateFile, runStarted)
+		}
+		return nil
+	}
+
+	cp, err := openCheckpoint(opts.CheckpointDir, opts.Resume)
+	if err != nil {
+		return fmt.Errorf("error opening checkpoint: %w", err)
+	}
+	defer cp.Close()
+	if opts.Resume {
+		slog.Info("Resuming from checkpoint", "dir", opts.CheckpointDir, "pages", len(cp.donePages), "movies", len(cp.doneMovies))
+	}
+
+	slog.Info("Fetching movie IDs from TMDB discover endpoint...")
+	movieIDs := discoverMovieIDs(ctx, f, gate, cp, opts.Workers, failures)
+	slog.Info("Discovered unique movie IDs", "count", len(movieIDs))
+
+	var valid []*model.Movie
+	for _, movie := range fetchMovies(ctx, f, gate, cp, movieIDs, opts.Workers, failures) {
+		if movie != nil {
+			valid = append(valid, movie)
+		}
+	}
+	slog.Info("Fetched and processed valid movies", "count", len(valid))
+	logLines(gate.Report())
+
+	if !reportFailures(failures) {
+		slog.Warn("Output files left unchanged and checkpoint kept; rerun with -resume to retry only the failed units.", "dir", opts.CheckpointDir)
+		return nil
+	}
+	addPersonImdbIDs(ctx, f, valid, opts.Workers)
+	finalMovies := make([]model.Movie, len(valid))
+	for i, movie := range valid {
+		finalMovies[i] = *movie
+	}
+	if err := writeOutputs(opts.DataDir, finalMovies, opts.Diff); err != nil {
+		return err
+	}
+	finishRun(stateFile, runStarted)
+	if err := cp.Remove(); err != nil {
+		slog.Warn("Could not remove checkpoint directory", "err", err)
+	}
+	return nil
+}
+
+func logLines(lines []string) {
+	for _, line := range lines {
+		slog.Info(line)
+	}
+}
+
+// reportFailures logs permanent failures and returns whether there were
+// none.
+func reportFailures(failures *failureLog) bool {
+	lines := failures.Lines()
+	if len(lines) == 0 {
+		return true
+	}
+	slog.Warn("Pipeline completed with permanent failures", "count", len(lines))
+	for _, line := range lines {
+		slog.Warn("  " + line)
+	}
+	return false
+}
+
+// finishRun records a clean run in stateFile, if set. The start time is only
+// recorded for clean runs so the next incremental refresh never skips
+// changes to movies that failed this time.
+func finishRun(stateFile string, runStarted time.Time) {
+	if stateFile != "" {
+		if err := saveRunState(stateFile, runState{LastRun: runStarted}); err != nil {
+			slog.Warn("Could not record run state", "err", err)
+		}
+	}
+	slog.Info("Pipeline completed successfully!")
+}
@@ -0,0 +1,124 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/filters"
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/common/tmdb"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+)
+
+func testGate(t *testing.T, p filters.Profile) *filters.Gate {
+	t.Helper()
+	gate, err := (&filters.Config{Profiles: map[string]filters.Profile{"test": p}}).Gate("test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gate
+}
+
+func TestFetchMoviesRegatesJournaledMovies(t *testing.T) {
+	dir := t.TempDir()
+	cp, err := openCheckpoint(dir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	journaled := []*model.Movie{
+		{ID: 1, Title: "Alien", VoteCount: 500},
+		// Journaled under a looser profile.
+		{ID: 2, Title: "Rocky", VoteCount: 50},
+		nil,
+	}
+	for i, m := range journaled {
+		if err := cp.RecordMovie(i+1, m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cp.Close()
+	if cp, err = openCheckpoint(dir, true); err != nil {
+		t.Fatal(err)
+	}
+	defer cp.Close()
+
+	runtime, votes := 75, 100
+	gate := testGate(t, filters.Profile{RuntimeGT: &runtime, VoteCountGT: &votes})
+	// Every movie is journaled, so the client is never used.
+	results := fetchMovies(context.Background(), nil, gate, cp, []int{1, 2, 3}, 2, newFailureLog())
+	if results[0] == nil || results[0].Title != "Alien" {
+		t.Errorf("movie 1 = %v, want Alien", results[0])
+	}
+	if results[1] != nil {
+		t.Errorf("movie 2 = %v, want it dropped by the gate", results[1])
+	}
+	if results[2] != nil {
+		t.Errorf("movie 3 = %v, want nil", results[2])
+	}
+	want := `Filter profile "test": 2 checked, 1 accepted, 1 rejected`
+	if got := gate.Report()[0]; got != want {
+		t.Errorf("gate report = %q, want %q", got, want)
+	}
+}
+
+func TestRunKeepsOutputsOnFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/discover/movie" && r.URL.Query().Get("page") == "2":
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/discover/movie":
+			var results []map[string]int
+			if r.URL.Query().Get("page") == "1" {
+				results = []map[string]int{{"id": 1}}
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		case r.URL.Path == "/movie/1":
+			json.NewEncoder(w).Encode(tmdb.DetailsResponse{ID: 1, Title: "Alien", Overview: "A crew answers a distress call.", Runtime: 117, VoteCount: 500})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	f := tmdb.NewClient(tmdb.Credentials{APIKey: "test-key"})
+	f.BaseURL = srv.URL
+	f.Retry = tmdb.RetryPolicy{MaxAttempts: 1}
+
+	dataDir := t.TempDir()
+	path := filepath.Join(dataDir, datafile.PopularMovies)
+	if err := datafile.Write(path, []model.Movie{{ID: 2, Title: "Heat"}, {ID: 3, Title: "Jaws"}}); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkpointDir := filepath.Join(t.TempDir(), "checkpoint")
+	if err := Run(context.Background(), Options{
+		Client:        f,
+		Gate:          testGate(t, filters.Profile{}),
+		DataDir:       dataDir,
+		Workers:       4,
+		CheckpointDir: checkpointDir,
+		StateFile:     filepath.Join(t.TempDir(), "runState.json"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if after, _ := os.ReadFile(path); !bytes.Equal(after, before) {
+		t.Errorf("%s was overwritten by a run with failures", datafile.PopularMovies)
+	}
+	if _, err := os.Stat(filepath.Join(dataDir, datafile.BasicMovies)); !os.IsNotExist(err) {
+		t.Errorf("%s was written by a run with failures", datafile.BasicMovies)
+	}
+	if _, err := os.Stat(checkpointDir); err != nil {
+		t.Errorf("checkpoint was not kept: %v", err)
+	}
+}
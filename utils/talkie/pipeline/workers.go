@@ -0,0 +1,37 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+)
+
+// runPool calls fn for every index in [0, n) using at most workers goroutines
+// and returns once all calls have finished. Callers write results into a
+// pre-sized slice by index so output order never depends on completion order.
+func runPool(ctx context.Context, n, workers int, fn func(ctx context.Context, i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(ctx, i)
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case <-ctx.Done():
+			break feed
+		case jobs <- i:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+}
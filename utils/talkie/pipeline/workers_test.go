@@ -0,0 +1,45 @@
+package pipeline
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRunPoolVisitsEveryIndexOnce(t *testing.T) {
+	const n = 1000
+	var visits [n]atomic.Int32
+	runPool(context.Background(), n, 8, func(ctx context.Context, i int) {
+		visits[i].Add(1)
+	})
+	for i := range visits {
+		if got := visits[i].Load(); got != 1 {
+			t.Errorf("index %d visited %d times, want 1", i, got)
+		}
+	}
+}
+
+func TestRunPoolStopsOnCancel(t *testing.T) {
+	const n = 1000
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var visits [n]atomic.Int32
+	var calls atomic.Int32
+	runPool(ctx, n, 4, func(ctx context.Context, i int) {
+		visits[i].Add(1)
+		if calls.Add(1) == 10 {
+			cancel()
+		}
+	})
+
+	// Jobs already handed to a worker still run, but no more are fed.
+	if got := calls.Load(); got < 10 || got > 20 {
+		t.Errorf("runPool made %d calls after cancelling at 10, want a few more at most", got)
+	}
+	for i := range visits {
+		if got := visits[i].Load(); got > 1 {
+			t.Errorf("index %d visited %d times", i, got)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Package populate uploads the full movie details from popularMovies.json to
+// the Firestore movies collection.
+package populate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"cloud.google.com/go/firestore"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+)
+
+const batchSize = 400 // Firestore transaction limit is 500
+
+// Run writes every movie in dataDir's popularMovies.json to the movies
+// collection, keyed by TMDB ID.
+func Run(ctx context.Context, client *firestore.Client, dataDir string) error {
+	moviesJSONPath := filepath.Join(dataDir, datafile.PopularMovies)
+	var movies []model.Movie
+	if err := datafile.Read(moviesJSONPath, &movies); err != nil {
+		return fmt.Errorf("failed to read movies: %w", err)
+	}
+
+	slog.Info("Read movies", "count", len(movies), "path", moviesJSONPath)
+
+	batch := client.Batch()
+	moviesCollection := client.Collection("movies")
+	commitCounter := 0
+
+	for i, movie := range movies {
+		docID := strconv.Itoa(movie.ID)
+		docRef := moviesCollection.Doc(docID)
+		batch.Set(docRef, movie)
+
+		if (i+1)%batchSize == 0 || i == len(movies)-1 {
+			slog.Info("Committing batch", "batch", commitCounter+1)
+			_, err := batch.Commit(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to commit batch: %w", err)
+			}
+			slog.Debug("Committed batch", "batch", commitCounter+1)
+			batch = client.Batch()
+			commitCounter++
+			time.Sleep(500 * time.Millisecond)
+		}
+	}
+
+	slog.Info("Database successfully normalized to lowercase keys!")
+	return nil
+}
@@ -0,0 +1,132 @@
+package sanitize
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+// MaxClues is the most segments an overview is split into, one for each
+// guess the app allows.
+const MaxClues = 5
+
+// minClueWords is the fewest words a clause needs to make a clue of its own.
+const minClueWords = 6
+
+// clauseBreaks are where a long sentence may be split into clauses. The
+// punctuation stays with the clause before the break.
+var clauseBreaks = []string{"; ", ", ", " — ", " – ", ": "}
+
+// clueText returns the overview the app shows: the manual one if set,
+// otherwise the sanitized one.
+func clueText(m model.Movie) string {
+	if strings.TrimSpace(m.ManualOverview) != "" {
+		return m.ManualOverview
+	}
+	return m.Overview
+}
+
+// Clues splits overview into at most MaxClues segments and orders them
+// from vague to specific. Segments are sentences, with the longest split
+// at clause boundaries while there are fewer than MaxClues and the
+// shortest merged into a neighbour while there are more. A segment is as
+// specific as its words are rare in corpus; ties keep the overview's order.
+func Clues(overview string, corpus *Corpus) []string {
+	segments := Sentences(overview)
+	for len(segments) < MaxClues {
+		i, parts := -1, []string(nil)
+		for j, segment := range segments {
+			if p := splitClause(segment); p != nil && (i < 0 || len(words(segment)) > len(words(segments[i]))) {
+				i, parts = j, p
+			}
+		}
+		if i < 0 {
+			break
+		}
+		segments = append(segments[:i], append(parts, segments[i+1:]...)...)
+	}
+	for len(segments) > MaxClues {
+		i := 0
+		for j, segment := range segments {
+			if len(words(segment)) < len(words(segments[i])) {
+				i = j
+			}
+		}
+		// Merge with the shorter neighbour.
+		if i == len(segments)-1 || (i > 0 && len(words(segments[i-1])) < len(words(segments[i+1]))) {
+			i--
+		}
+		segments[i] += " " + segments[i+1]
+		segments = append(segments[:i+1], segments[i+2:]...)
+	}
+
+	scores := make(map[string]float64, len(segments))
+	for _, segment := range segments {
+		scores[segment] = corpus.Information(segment)
+	}
+	sort.SliceStable(segments, func(i, j int) bool {
+		return scores[segments[i]] < scores[segments[j]]
+	})
+	return segments
+}
+
+// splitClause splits s in two at the clause break closest to its middle
+// that leaves both halves at least minClueWords long, or returns nil.
+func splitClause(s string) []string {
+	total := len(words(s))
+	best, bestAt := -1, 0
+	for _, sep := range clauseBreaks {
+		for from := 0; ; {
+			i := strings.Index(s[from:], sep)
+			if i < 0 {
+				break
+			}
+			at := from + i + len(strings.TrimRight(sep, " "))
+			from = at
+			before := len(words(s[:at]))
+			if before < minClueWords || total-before < minClueWords || strings.Count(s[:at], "[") != strings.Count(s[:at], "]") {
+				continue
+			}
+			if distance := abs(2*before - total); best < 0 || distance < best {
+				best, bestAt = distance, at
+			}
+		}
+	}
+	if best < 0 {
+		return nil
+	}
+	return []string{strings.TrimSpace(s[:bestAt]), strings.TrimSpace(s[bestAt:])}
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// Information scores how specific s is: the mean inverse document
+// frequency of its content words across the corpus. Placeholders and stop
+// words carry no information, and a word no overview uses counts as
+// appearing in one.
+func (c *Corpus) Information(s string) float64 {
+	var sum float64
+	n := 0
+	for _, tok := range Tokens(s) {
+		if IsPlaceholder(s, tok) || stopWords[tok.Folded] {
+			continue
+		}
+		frequency := c.frequency[tok.Folded]
+		if frequency < 1 {
+			frequency = 1
+		}
+		sum += math.Log(float64(c.size+1) / float64(frequency))
+		n++
+	}
+	if n == 0 {
+		return 0
+	}
+	return sum / float64(n)
+}
@@ -0,0 +1,67 @@
+package sanitize
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func TestSentences(t *testing.T) {
+	got := Sentences("Marty McFly meets Dr. Brown.  Then time travel! And a trailing fragment")
+	want := []string{"Marty McFly meets Dr. Brown.", "Then time travel!", "And a trailing fragment"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sentences = %q, want %q", got, want)
+	}
+	if got := Sentences("  "); got != nil {
+		t.Errorf("Sentences of blank = %q, want nil", got)
+	}
+}
+
+func TestClues(t *testing.T) {
+	corpus := NewCorpus([]string{
+		"A man returns home to find his town changed.",
+		"A woman searches for her missing brother in the city.",
+		"A young detective investigates a murder in a small town.",
+		"Zorvath the sorcerer hunts the wizard Quellin across the Obsidian Wastes.",
+	})
+
+	got := Clues("Zorvath the sorcerer hunts the wizard Quellin across the Obsidian Wastes. A man returns home to find his town changed.", corpus)
+	want := []string{
+		"A man returns home to find his town changed.",
+		"Zorvath the sorcerer hunts the wizard Quellin across the Obsidian Wastes.",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Clues = %q, want %q", got, want)
+	}
+
+	// A long sentence is split at the clause break nearest its middle.
+	got = Clues("After a long night in the city, a young detective investigates a murder in a small town.", corpus)
+	want = []string{
+		"a young detective investigates a murder in a small town.",
+		"After a long night in the city,",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Clues = %q, want %q", got, want)
+	}
+
+	// Short sentences are merged down to MaxClues.
+	got = Clues("One. Two. Three. Four. Five. Six. Seven.", corpus)
+	if len(got) != MaxClues {
+		t.Errorf("got %d clues %q, want %d", len(got), got, MaxClues)
+	}
+}
+
+func TestAddOverviewsClues(t *testing.T) {
+	movies := []model.Movie{
+		{Title: "Heat", OriginalOverview: "A thief plans one last job.", Overview: "A thief plans one last job."},
+		{Title: "Up", Overview: "An old man flies his house.", ManualOverview: "A balloon salesman sets off. His house flies."},
+	}
+	AddOverviews(movies)
+	if want := []string{"A thief plans one last job."}; !reflect.DeepEqual(movies[0].Clues, want) {
+		t.Errorf("clues = %q, want %q", movies[0].Clues, want)
+	}
+	if len(movies[1].Clues) != 2 {
+		t.Errorf("clues = %q, want the manual overview's two sentences", movies[1].Clues)
+	}
+}
@@ -0,0 +1,201 @@
+package sanitize
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// stripMarks decomposes text (NFD) and drops the combining marks, so "é"
+// becomes "e" whether it was written precomposed or not.
+var stripMarks = transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)))
+
+// letterFolds covers letters that have no canonical decomposition, and
+// typographic apostrophes, which TMDB overviews use interchangeably with '.
+var letterFolds = map[rune]string{
+	'ø': "o", 'æ': "ae", 'œ': "oe", 'ß': "ss", 'ł': "l", 'đ': "d", 'ð': "d", 'þ': "th", 'ı': "i",
+	'’': "'", '‘': "'",
+}
+
+// Fold lowercases s and strips its diacritics, so "Amélie", "AMELIE" and
+// "amelie" compare equal.
+func Fold(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		b.WriteString(foldRune(r))
+	}
+	return b.String()
+}
+
+func foldRune(r rune) string {
+	r = unicode.ToLower(r)
+	if f, ok := letterFolds[r]; ok {
+		return f
+	}
+	if r < utf8.RuneSelf {
+		return string(r)
+	}
+	f, _, _ := transform.String(stripMarks, string(r))
+	return f
+}
+
+// isWordRune reports whether r can be part of a word. Like \b in a regexp,
+// but for every script rather than only ASCII.
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsNumber(r) || unicode.IsMark(r)
+}
+
+// words splits folded text into words, keeping apostrophes inside them so
+// "schindler's" stays one word.
+func words(folded string) []string {
+	return strings.FieldsFunc(folded, func(r rune) bool {
+		return r != '\'' && !isWordRune(r)
+	})
+}
+
+// Token is a word in a text, with its folded form and its byte offsets.
+type Token struct {
+	Text       string
+	Folded     string
+	Start, End int
+}
+
+// Tokens splits s into words the way the sanitizer matches them, keeping
+// apostrophes inside words.
+func Tokens(s string) []Token {
+	var tokens []Token
+	start := -1
+	flush := func(end int) {
+		if start < 0 {
+			return
+		}
+		text := strings.Trim(s[start:end], "'’")
+		if text != "" {
+			offset := start + strings.Index(s[start:end], text)
+			tokens = append(tokens, Token{Text: text, Folded: Fold(text), Start: offset, End: offset + len(text)})
+		}
+		start = -1
+	}
+	for i, r := range s {
+		if isWordRune(r) || r == '\'' || r == '’' {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		flush(i)
+	}
+	flush(len(s))
+	return tokens
+}
+
+// foldedText is a folded copy of a text that remembers where each folded
+// byte came from, so matches on the folded copy can be replaced in the
+// original.
+type foldedText struct {
+	text       string
+	start, end []int // original byte range of each folded byte
+}
+
+func newFoldedText(s string) *foldedText {
+	f := &foldedText{}
+	var b strings.Builder
+	for i, r := range s {
+		next := i + utf8.RuneLen(r)
+		folded := foldRune(r)
+		if folded == "" {
+			// A lone combining mark belongs to the letter before it.
+			if n := len(f.end); n > 0 {
+				f.end[n-1] = next
+			}
+			continue
+		}
+		b.WriteString(folded)
+		for range len(folded) {
+			f.start = append(f.start, i)
+			f.end = append(f.end, next)
+		}
+	}
+	f.text = b.String()
+	return f
+}
+
+// find returns the original byte ranges of every occurrence of the folded
+// phrase that starts and ends on a word boundary.
+func (f *foldedText) find(phrase string) [][2]int {
+	var spans [][2]int
+	if phrase == "" {
+		return spans
+	}
+	for offset := 0; ; {
+		i := strings.Index(f.text[offset:], phrase)
+		if i < 0 {
+			return spans
+		}
+		from, to := offset+i, offset+i+len(phrase)
+		if f.boundary(from) && f.boundary(to) {
+			spans = append(spans, [2]int{f.start[from], f.end[to-1]})
+		}
+		_, size := utf8.DecodeRuneInString(f.text[from:])
+		offset = from + size
+	}
+}
+
+// boundary reports whether offset in the folded text sits between a word
+// rune and a non-word rune, or at either end.
+func (f *foldedText) boundary(offset int) bool {
+	before, after := false, false
+	if offset > 0 {
+		r, _ := utf8.DecodeLastRuneInString(f.text[:offset])
+		before = isWordRune(r)
+	}
+	if offset < len(f.text) {
+		r, _ := utf8.DecodeRuneInString(f.text[offset:])
+		after = isWordRune(r)
+	}
+	return before != after
+}
+
+// replacePhrases replaces every whole-word, case- and accent-insensitive
+// occurrence of each folded phrase in s with its placeholder. Where matches
+// overlap, the earliest and then the longest wins.
+func replacePhrases(s string, phrases map[string]string) string {
+	type match struct {
+		start, end  int
+		placeholder string
+	}
+	f := newFoldedText(s)
+	var matches []match
+	for phrase, placeholder := range phrases {
+		for _, span := range f.find(phrase) {
+			matches = append(matches, match{span[0], span[1], placeholder})
+		}
+	}
+	if len(matches) == 0 {
+		return s
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].start != matches[j].start {
+			return matches[i].start < matches[j].start
+		}
+		return matches[i].end > matches[j].end
+	})
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		if m.start < last {
+			continue
+		}
+		b.WriteString(s[last:m.start])
+		b.WriteString(m.placeholder)
+		last = m.end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
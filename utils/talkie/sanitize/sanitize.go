@@ -0,0 +1,254 @@
+// Package sanitize removes words from movie overviews that would give the
+// answer away, such as the title, the billed cast or their characters.
+package sanitize
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+var stopWords = map[string]bool{
+	"the": true, "a": true, "an": true, "of": true, "in": true, "and": true, "or": true,
+	"&": true, "to": true, "is": true, "on": true, "for": true, "with": true, "s": true,
+	"from": true, "by": true, "at": true, "part": true, "i": true, "ii": true, "iii": true,
+}
+
+// genericWords are the parts of character credits that describe rather than
+// name someone, e.g. "Detective", "Young" or "Man" in "Man at Bar". Redacting
+// them on their own would blank out ordinary words in the overview.
+var genericWords = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "miss": true, "dr": true, "doctor": true, "sir": true, "lady": true,
+	"lord": true, "king": true, "queen": true, "prince": true, "princess": true, "captain": true,
+	"agent": true, "officer": true, "detective": true, "sergeant": true, "lieutenant": true, "general": true,
+	"professor": true, "father": true, "mother": true, "uncle": true, "aunt": true, "young": true,
+	"old": true, "little": true, "big": true, "man": true, "woman": true, "boy": true, "girl": true,
+	"voice": true, "uncredited": true, "self": true, "himself": true, "herself": true,
+}
+
+// collectionWords are how TMDB collection names end, as in "The Avengers
+// Collection", and say nothing about the franchise.
+var collectionWords = map[string]bool{
+	"collection": true, "saga": true, "trilogy": true, "series": true, "franchise": true, "anthology": true,
+}
+
+// villainMarkers in a character credit mark the role as an antagonist.
+// TMDB has no notion of villains, so this only catches credits that say so,
+// e.g. "The Joker", "Darth Vader" or "Killer".
+var villainMarkers = []string{
+	"villain", "killer", "murderer", "assassin", "kidnapper", "henchman", "darth", "joker", "evil", "dark lord", "nemesis",
+}
+
+// Placeholders stand in for redacted words, by the role of whoever they
+// name. Title words are redacted as the protagonist.
+const (
+	Protagonist = "[Protagonist]"
+	Character   = "[Character]"
+	Villain     = "[Villain]"
+)
+
+// BilledCast is how many of the top-billed cast have their names and
+// characters redacted.
+const BilledCast = 5
+
+// rolePriority decides which placeholder a word gets when it names more
+// than one role.
+var rolePriority = map[string]int{Character: 1, Villain: 2, Protagonist: 3}
+
+// Overview returns m's original overview with title words, the full title,
+// the words of its collection's name and the names and characters of the
+// top-billed cast replaced by a placeholder for their role: the title,
+// collection, first-billed actor and their character are the protagonist,
+// characters credited as villains are the villain and everyone else is a
+// character. m.Actors must be sorted by billing order. Matching ignores case
+// and diacritics, so "Amelie" in an overview is caught for the title
+// "Amélie" and vice versa.
+func Overview(m model.Movie) string {
+	return redact(m, m.OriginalOverview)
+}
+
+// redact replaces the words of text that give m away, as described for
+// Overview.
+func redact(m model.Movie, text string) string {
+	sensitiveWords := sensitiveWords(m)
+	if len(sensitiveWords) == 0 {
+		return text
+	}
+	text = replacePhrases(text, sensitiveWords)
+	return replacePhrases(text, titlePhrases(m))
+}
+
+// titles returns m's title and alternative titles.
+func titles(m model.Movie) []string {
+	return append([]string{m.Title}, m.AltTitles...)
+}
+
+// titlePhrases maps each of m's folded titles to the protagonist
+// placeholder.
+func titlePhrases(m model.Movie) map[string]string {
+	phrases := make(map[string]string)
+	for _, title := range titles(m) {
+		phrases[Fold(title)] = Protagonist
+	}
+	return phrases
+}
+
+// sensitiveWords maps the folded words and phrases that give m away to
+// their placeholders.
+func sensitiveWords(m model.Movie) map[string]string {
+	topCast := m.Actors
+
+	sensitiveWords := make(map[string]string)
+	add := func(word, placeholder string) {
+		if current, ok := sensitiveWords[word]; !ok || rolePriority[placeholder] > rolePriority[current] {
+			sensitiveWords[word] = placeholder
+		}
+	}
+
+	for _, title := range titles(m) {
+		for word := range titleWords(title) {
+			add(word, Protagonist)
+		}
+	}
+
+	// Sequels rarely repeat every title word, but their collection's name
+	// gives the franchise away just the same: "Avengers", "Potter", "Bond".
+	if m.Collection != nil {
+		for _, word := range words(Fold(m.Collection.Name)) {
+			if !stopWords[word] && !collectionWords[word] && utf8.RuneCountInString(word) > 2 {
+				add(word, Protagonist)
+			}
+		}
+	}
+
+	for i, actor := range topCast {
+		if i == BilledCast {
+			break
+		}
+		placeholder := role(i, actor.Character)
+		for _, part := range strings.Fields(Fold(actor.Name)) {
+			if !stopWords[part] && utf8.RuneCountInString(part) > 2 {
+				add(part, placeholder)
+			}
+		}
+		for _, name := range characterNames(actor.Character) {
+			add(name, placeholder)
+			parts := words(name)
+			if descriptive(parts) {
+				continue
+			}
+			for _, part := range parts {
+				if !genericWords[part] && utf8.RuneCountInString(part) > 2 {
+					add(part, placeholder)
+				}
+			}
+		}
+	}
+
+	return sensitiveWords
+}
+
+// Movie sanitizes m's overview and tagline in place from their originals.
+func Movie(m *model.Movie) {
+	m.Overview = Overview(*m)
+	m.Tagline, m.TaglineUnsafe = Tagline(*m)
+}
+
+// Tagline returns m's original tagline redacted like the overview, and
+// whether it is unsafe to show at all. A tagline that is mostly the title,
+// franchise or cast, like "Avengers Assemble.", gives the movie away even
+// with those words replaced, and is returned empty.
+func Tagline(m model.Movie) (string, bool) {
+	tokens := Tokens(m.OriginalTagline)
+	if len(tokens) == 0 {
+		return "", false
+	}
+	tagline := redact(m, m.OriginalTagline)
+
+	redacted := 0
+	for _, tok := range Tokens(tagline) {
+		if IsPlaceholder(tagline, tok) {
+			redacted++
+		}
+	}
+	// Half placeholders reads as a fill-in-the-blank for the title.
+	if redacted*2 >= len(tokens) || leaksTitle(m, tagline) {
+		return "", true
+	}
+	return tagline, false
+}
+
+// inflections are the endings leaksTitle allows after a title word's stem.
+var inflections = map[string]bool{"": true, "s": true, "'s": true, "s'": true, "es": true, "er": true, "ers": true, "ed": true, "ing": true}
+
+// leaksTitle reports whether text still contains a variant of a title or
+// collection word that exact matching missed, such as "Avenger" for
+// "Avengers" or "Potter's" for "Potter".
+func leaksTitle(m model.Movie, text string) bool {
+	names := strings.Join(titles(m), " ")
+	if m.Collection != nil {
+		names += " " + m.Collection.Name
+	}
+	var stems []string
+	for _, tok := range Tokens(names) {
+		if !stopWords[tok.Folded] && !collectionWords[tok.Folded] && utf8.RuneCountInString(tok.Folded) > 3 {
+			stems = append(stems, strings.TrimSuffix(strings.TrimSuffix(tok.Folded, "'s"), "s"))
+		}
+	}
+	for _, tok := range Tokens(text) {
+		if IsPlaceholder(text, tok) {
+			continue
+		}
+		for _, stem := range stems {
+			if rest, ok := strings.CutPrefix(tok.Folded, stem); ok && inflections[rest] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// role returns the placeholder for the cast member billed at index i.
+func role(i int, character string) string {
+	if i == 0 {
+		return Protagonist
+	}
+	padded := " " + strings.Join(words(Fold(character)), " ") + " "
+	for _, marker := range villainMarkers {
+		if strings.Contains(padded, " "+marker+" ") {
+			return Villain
+		}
+	}
+	return Character
+}
+
+// descriptive reports whether a character credit describes a role, like
+// "Man at Market", rather than naming someone. Descriptive credits are only
+// redacted as a whole phrase, never word by word.
+func descriptive(parts []string) bool {
+	for _, part := range parts {
+		if stopWords[part] {
+			return true
+		}
+	}
+	return false
+}
+
+var parenthetical = regexp.MustCompile(`\([^)]*\)`)
+
+// characterNames splits a character credit such as
+// "Bruce Wayne / Batman (voice)" into the folded names it contains,
+// skipping credits that are not characters, like "Himself".
+func characterNames(character string) []string {
+	var names []string
+	for _, name := range strings.Split(parenthetical.ReplaceAllString(Fold(character), ""), "/") {
+		name = strings.Join(strings.Fields(name), " ")
+		if name == "" || genericWords[name] {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
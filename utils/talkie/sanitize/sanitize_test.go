@@ -0,0 +1,253 @@
+package sanitize
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func TestFold(t *testing.T) {
+	for in, want := range map[string]string{
+		"Amélie":             "amelie",
+		"AMÉLIE":             "amelie",
+		"Amélie":            "amelie", // decomposed input
+		"Løve":               "love",
+		"Zoë":                "zoe",
+		"Pokémon":            "pokemon",
+		"Das weiße Band":     "das weisse band",
+		"Y Tu Mamá También":  "y tu mama tambien",
+		"Schindler’s List":   "schindler's list",
+		"Crouching Tiger 臥虎": "crouching tiger 臥虎",
+	} {
+		if got := Fold(in); got != want {
+			t.Errorf("Fold(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestOverview(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		lead     string
+		overview string
+		want     string
+	}{
+		{
+			title:    "Amélie",
+			lead:     "Audrey Tautou",
+			overview: "Amélie is an innocent and naive girl in Paris. Audrey Tautou stars as Amelie.",
+			want:     "[Protagonist] is an innocent and naive girl in Paris. [Protagonist] [Protagonist] stars as [Protagonist].",
+		},
+		{
+			title:    "Pokémon Detective Pikachu",
+			lead:     "Ryan Reynolds",
+			overview: "In a world where people collect Pokemon, a young man teams up with Detective Pikachu.",
+			want:     "In a world where people collect [Protagonist], a young man teams up with [Protagonist] [Protagonist].",
+		},
+		{
+			title:    "Kimi",
+			lead:     "Zoë Kravitz",
+			overview: "Angela (Zoe Kravitz), an agoraphobic tech worker, uncovers evidence of a crime.",
+			want:     "Angela ([Protagonist] [Protagonist]), an agoraphobic tech worker, uncovers evidence of a crime.",
+		},
+		{
+			title:    "Død snø",
+			lead:     "Vegar Hoel",
+			overview: "Medical students on a ski trip meet the Nazi zombies of Død Snø, or as the locals spell it, DOD SNO.",
+			want:     "Medical students on a ski trip meet the Nazi zombies of [Protagonist] [Protagonist], or as the locals spell it, [Protagonist] [Protagonist].",
+		},
+		{
+			title:    "Les Misérables",
+			lead:     "Hugh Jackman",
+			overview: "In 19th-century France, Jean Valjean is hunted for decades. Les Miserables follows him.",
+			want:     "In 19th-century France, Jean Valjean is hunted for decades. [Protagonist] [Protagonist] follows him.",
+		},
+		{
+			title:    "Das weiße Band",
+			lead:     "Christian Friedel",
+			overview: "Strange events in a German village foreshadow the WEISSE BAND of fascism.",
+			want:     "Strange events in a German village foreshadow the [Protagonist] [Protagonist] of fascism.",
+		},
+		{
+			// Decomposed text keeps no stray combining marks after replacement.
+			title:    "Léon: The Professional",
+			lead:     "Jean Reno",
+			overview: "Léon, the top hit man in New York, takes in Mathilda. A professional.",
+			want:     "[Protagonist], the top hit man in New York, takes in Mathilda. A [Protagonist].",
+		},
+		{
+			// Apostrophes stay part of title words, and substrings don't match.
+			title:    "Schindler's List",
+			lead:     "Liam Neeson",
+			overview: "Schindler’s workers keep lists. Oskar Schindler's list saves lives.",
+			want:     "[Protagonist] workers keep lists. Oskar [Protagonist] [Protagonist] saves lives.",
+		},
+		{
+			// Titles with no sensitive words are only replaced as a whole.
+			title:    "Up",
+			lead:     "Ed Asner",
+			overview: "Carl ties balloons up to his house. Up goes the house.",
+			want:     "Carl ties balloons [Protagonist] to his house. [Protagonist] goes the house.",
+		},
+	} {
+		m := model.Movie{Title: tc.title, OriginalOverview: tc.overview, Actors: []model.MovieActor{{Name: tc.lead}}}
+		if got := Overview(m); got != tc.want {
+			t.Errorf("Overview(%q):\n got %q\nwant %q", tc.title, got, tc.want)
+		}
+	}
+}
+
+func TestOverviewCharacters(t *testing.T) {
+	for _, tc := range []struct {
+		title    string
+		cast     []model.MovieActor
+		overview string
+		want     string
+	}{
+		{
+			title: "Star Wars",
+			cast: []model.MovieActor{
+				{Name: "Mark Hamill", Character: "Luke Skywalker"},
+				{Name: "Harrison Ford", Character: "Han Solo"},
+				{Name: "Carrie Fisher", Character: "Princess Leia Organa"},
+				{Name: "Peter Cushing", Character: "Grand Moff Tarkin"},
+				{Name: "David Prowse", Character: "Darth Vader"},
+			},
+			overview: "Princess Leia is captured by Darth Vader. Luke Skywalker teams up with Han Solo to rescue the princess.",
+			want:     "Princess [Character] is captured by [Villain]. [Protagonist] teams up with [Character] to rescue the princess.",
+		},
+		{
+			title: "Back to the Future",
+			cast: []model.MovieActor{
+				{Name: "Michael J. Fox", Character: "Marty McFly"},
+				{Name: "Christopher Lloyd", Character: "Dr. Emmett Brown"},
+			},
+			overview: "Marty McFly is sent back in time by his friend, Doc Brown, played by Christopher Lloyd. McFly must make sure his parents meet.",
+			want:     "[Protagonist] is sent [Protagonist] in time by his friend, Doc [Character], played by [Character] [Character]. [Protagonist] must make sure his parents meet.",
+		},
+		{
+			title: "The Dark Knight",
+			cast: []model.MovieActor{
+				{Name: "Christian Bale", Character: "Bruce Wayne / Batman"},
+				{Name: "Heath Ledger", Character: "Joker"},
+			},
+			overview: "Batman raises the stakes in his war on crime, until the Joker pushes Bruce Wayne to the edge.",
+			want:     "[Protagonist] raises the stakes in his war on crime, until the [Villain] pushes [Protagonist] to the edge.",
+		},
+		{
+			// Credits that are not names are left alone, as is anyone billed
+			// below the top five.
+			title: "Jiro Dreams of Sushi",
+			cast: []model.MovieActor{
+				{Name: "Jiro Ono", Character: "Himself"},
+				{Name: "Yoshikazu Ono", Character: "Himself"},
+				{Name: "A", Character: "Young Apprentice"},
+				{Name: "B", Character: "Man at Market"},
+				{Name: "C", Character: "Food Critic (voice)"},
+				{Name: "Masuhiro Yamamoto", Character: "Himself"},
+			},
+			overview: "A young apprentice watches himself and the man at the market. Yamamoto, a food critic, explains.",
+			want:     "A [Character] watches himself and the man at the market. Yamamoto, a [Character], explains.",
+		},
+	} {
+		m := model.Movie{Title: tc.title, OriginalOverview: tc.overview, Actors: tc.cast}
+		if got := Overview(m); got != tc.want {
+			t.Errorf("Overview(%q):\n got %q\nwant %q", tc.title, got, tc.want)
+		}
+	}
+}
+
+func TestOverviewCollection(t *testing.T) {
+	for _, tc := range []struct {
+		title      string
+		collection string
+		overview   string
+		want       string
+	}{
+		{
+			title:      "Avengers: Endgame",
+			collection: "The Avengers Collection",
+			overview:   "After the devastating events of Avengers: Infinity War, the Avengers assemble once more.",
+			want:       "After the devastating events of [Protagonist]: Infinity War, the [Protagonist] assemble once more.",
+		},
+		{
+			title:      "Harry Potter and the Chamber of Secrets",
+			collection: "Harry Potter Collection",
+			overview:   "Cars fly, trees fight back, and a mysterious house-elf comes to warn Harry Potter at the start of his second year.",
+			want:       "Cars fly, trees fight back, and a mysterious house-elf comes to warn [Protagonist] [Protagonist] at the start of his second year.",
+		},
+		{
+			title:      "Skyfall",
+			collection: "James Bond Collection",
+			overview:   "Bond's loyalty to M is tested when her past comes back to haunt her. A collection of secrets is stolen.",
+			want:       "[Protagonist]'s loyalty to M is tested when her past comes back to haunt her. A collection of secrets is stolen.",
+		},
+	} {
+		m := model.Movie{
+			Title:            tc.title,
+			OriginalOverview: tc.overview,
+			Collection:       &model.Collection{ID: 1, Name: tc.collection},
+		}
+		if got := Overview(m); got != tc.want {
+			t.Errorf("Overview(%q):\n got %q\nwant %q", tc.title, got, tc.want)
+		}
+	}
+}
+
+func TestOverviewAltTitles(t *testing.T) {
+	m := model.Movie{
+		Title:            "Edge of Tomorrow",
+		AltTitles:        []string{"Live Die Repeat: Edge of Tomorrow"},
+		OriginalOverview: "An officer is caught in a time loop of war: live, die, repeat.",
+	}
+	want := "An officer is caught in a time loop of war: [Protagonist], [Protagonist], [Protagonist]."
+	if got := Overview(m); got != want {
+		t.Errorf("Overview:\n got %q\nwant %q", got, want)
+	}
+	if got := Overviews(m, NewCorpus(nil))[Easy]; got != want {
+		t.Errorf("easy overview:\n got %q\nwant %q", got, want)
+	}
+	if _, unsafe := Tagline(model.Movie{Title: m.Title, AltTitles: m.AltTitles, OriginalTagline: "Live. Die. Repeat."}); !unsafe {
+		t.Error("Tagline kept an alternative title")
+	}
+}
+
+func TestTokens(t *testing.T) {
+	s := "'Léon's' 12-year-old [Protagonist]"
+	var got []string
+	for _, tok := range Tokens(s) {
+		if s[tok.Start:tok.End] != tok.Text {
+			t.Errorf("token %q has offsets %d-%d", tok.Text, tok.Start, tok.End)
+		}
+		got = append(got, tok.Text+"="+tok.Folded)
+	}
+	want := "Léon's=leon's 12=12 year=year old=old Protagonist=protagonist"
+	if strings.Join(got, " ") != want {
+		t.Errorf("Tokens(%q) = %v, want %s", s, got, want)
+	}
+}
+
+func TestTagline(t *testing.T) {
+	for _, tc := range []struct {
+		title, collection, tagline string
+		want                       string
+		unsafe                     bool
+	}{
+		{title: "Alien", tagline: "In space no one can hear you scream.", want: "In space no one can hear you scream."},
+		{title: "Jaws 2", tagline: "Just when you thought it was safe to go back in the water... Jaws returns.", want: "Just when you thought it was safe to go back in the water... [Protagonist] returns."},
+		{title: "The Avengers", collection: "The Avengers Collection", tagline: "Avengers Assemble!", unsafe: true},
+		{title: "Avengers: Age of Ultron", collection: "The Avengers Collection", tagline: "A new age begins for every Avenger.", unsafe: true},
+		{title: "Star Wars", tagline: "A long time ago the adventure would start.", want: "A long time ago the adventure would start."},
+		{title: "Up", tagline: "", want: ""},
+	} {
+		m := model.Movie{Title: tc.title, OriginalTagline: tc.tagline}
+		if tc.collection != "" {
+			m.Collection = &model.Collection{ID: 1, Name: tc.collection}
+		}
+		got, unsafe := Tagline(m)
+		if got != tc.want || unsafe != tc.unsafe {
+			t.Errorf("Tagline(%q) = %q, %v; want %q, %v", tc.tagline, got, unsafe, tc.want, tc.unsafe)
+		}
+	}
+}
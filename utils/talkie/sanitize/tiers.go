@@ -0,0 +1,293 @@
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+// Difficulty tiers of the overview variants, the keys of Movie.Overviews.
+// Easy redacts only the title, medium also every name and place, and hard
+// also distinctive nouns and numbers, keeping only the first sentence.
+const (
+	Easy   = "easy"
+	Medium = "medium"
+	Hard   = "hard"
+)
+
+// Placeholders for the redactions that only the harder tiers make.
+const (
+	Name   = "[Name]"
+	Place  = "[Place]"
+	Number = "[Number]"
+	Thing  = "[Thing]"
+)
+
+// placePrepositions introduce a place rather than a person: "in Paris",
+// "to Mordor".
+var placePrepositions = map[string]bool{
+	"in": true, "to": true, "from": true, "at": true, "near": true, "across": true,
+	"through": true, "into": true, "outside": true, "around": true,
+}
+
+var numberWords = map[string]bool{
+	"two": true, "three": true, "four": true, "five": true, "six": true, "seven": true, "eight": true,
+	"nine": true, "ten": true, "eleven": true, "twelve": true, "twenty": true, "thirty": true, "forty": true,
+	"fifty": true, "hundred": true, "hundreds": true, "thousand": true, "thousands": true, "million": true, "millions": true,
+}
+
+// nonNounSuffixes mark words that are most likely adverbs, verbs or
+// adjectives, which say little about the movie even when rare.
+var nonNounSuffixes = []string{"ly", "ing", "ed", "er", "est", "ous", "ive", "ful", "less", "able", "ible", "ize", "izes", "ise", "ises"}
+
+// Corpus holds how many overviews each word appears in, to tell the words
+// that describe one movie from those that could describe any.
+type Corpus struct {
+	frequency map[string]int
+	size      int
+}
+
+// NewCorpus counts the words of the given overviews.
+func NewCorpus(overviews []string) *Corpus {
+	c := &Corpus{frequency: make(map[string]int), size: len(overviews)}
+	for _, overview := range overviews {
+		seen := make(map[string]bool)
+		for _, tok := range Tokens(overview) {
+			seen[tok.Folded] = true
+		}
+		for word := range seen {
+			c.frequency[word]++
+		}
+	}
+	return c
+}
+
+// Frequency returns the number of overviews that contain the folded word.
+func (c *Corpus) Frequency(word string) int {
+	return c.frequency[word]
+}
+
+// Distinctive reports whether a folded word appears in no other overview,
+// so that it narrows down the movie.
+func (c *Corpus) Distinctive(word string) bool {
+	return c.frequency[word] <= 1
+}
+
+// AddOverviews sets the tiered overview variants and the clues on every
+// movie, using the movies' own overviews as the corpus.
+func AddOverviews(movies []model.Movie) {
+	overviews := make([]string, len(movies))
+	for i, m := range movies {
+		overviews[i] = m.OriginalOverview
+	}
+	corpus := NewCorpus(overviews)
+	for i := range movies {
+		movies[i].Overviews = Overviews(movies[i], corpus)
+		movies[i].Clues = Clues(clueText(movies[i]), corpus)
+	}
+}
+
+// Overviews returns the easy, medium and hard variants of m's original
+// overview. m.Actors must be sorted by billing order.
+func Overviews(m model.Movie, corpus *Corpus) map[string]string {
+	easy := m.OriginalOverview
+	for _, title := range titles(m) {
+		easy = replacePhrases(easy, titleWords(title))
+	}
+	easy = replacePhrases(easy, titlePhrases(m))
+
+	medium := redactProperNouns(Overview(m))
+
+	hard := redactProperNouns(Overview(model.Movie{
+		Title:            m.Title,
+		AltTitles:        m.AltTitles,
+		OriginalOverview: FirstSentence(m.OriginalOverview),
+		Actors:           m.Actors,
+		Collection:       m.Collection,
+	}))
+	hard = redactDistinctive(hard, corpus)
+
+	return map[string]string{Easy: easy, Medium: medium, Hard: hard}
+}
+
+// titleWords returns the words of title worth redacting.
+func titleWords(title string) map[string]string {
+	sensitive := make(map[string]string)
+	for _, word := range words(Fold(title)) {
+		if !stopWords[word] && utf8.RuneCountInString(word) > 2 {
+			sensitive[word] = Protagonist
+		}
+	}
+	return sensitive
+}
+
+// IsPlaceholder reports whether tok is the word inside a placeholder such
+// as "[Protagonist]".
+func IsPlaceholder(s string, tok Token) bool {
+	return tok.Start > 0 && s[tok.Start-1] == '[' && tok.End < len(s) && s[tok.End] == ']'
+}
+
+// ProperNoun reports whether tokens[i] of s is capitalized mid-sentence,
+// the best guess at a name or place without a tagger.
+func ProperNoun(s string, tokens []Token, i int) bool {
+	tok := tokens[i]
+	first, _ := utf8.DecodeRuneInString(tok.Text)
+	if !unicode.IsUpper(first) || IsPlaceholder(s, tok) {
+		return false
+	}
+	before := strings.TrimRight(s[:tok.Start], " \t\n\"'“‘(")
+	return before != "" && !strings.ContainsAny(before[len(before)-1:], ".!?:")
+}
+
+// redactProperNouns replaces each run of proper nouns, such as "New York",
+// with one placeholder: a place after a preposition like "in", otherwise a
+// name. A capitalized word at the start of a sentence counts when it is
+// used as a name elsewhere in s or starts a run, as in "Xander Cage".
+func redactProperNouns(s string) string {
+	tokens := Tokens(s)
+	proper := make([]bool, len(tokens))
+	names := make(map[string]bool)
+	for i := range tokens {
+		if ProperNoun(s, tokens, i) && !stopWords[tokens[i].Folded] {
+			proper[i] = true
+			names[strings.TrimSuffix(tokens[i].Folded, "'s")] = true
+		}
+	}
+	for i := len(tokens) - 1; i >= 0; i-- {
+		tok := tokens[i]
+		first, _ := utf8.DecodeRuneInString(tok.Text)
+		if proper[i] || !unicode.IsUpper(first) || IsPlaceholder(s, tok) || stopWords[tok.Folded] {
+			continue
+		}
+		startsRun := i+1 < len(tokens) && proper[i+1] && strings.TrimSpace(s[tok.End:tokens[i+1].Start]) == ""
+		proper[i] = names[strings.TrimSuffix(tok.Folded, "'s")] || startsRun
+	}
+
+	var b strings.Builder
+	last := 0
+	for i := 0; i < len(tokens); i++ {
+		if !proper[i] {
+			continue
+		}
+		start, end := tokens[i].Start, tokens[i].End
+		for i+1 < len(tokens) && proper[i+1] && strings.TrimSpace(s[end:tokens[i+1].Start]) == "" {
+			i++
+			end = tokens[i].End
+		}
+		placeholder := Name
+		if placePrepositions[precedingWord(tokens, start)] {
+			placeholder = Place
+		}
+		b.WriteString(s[last:start])
+		b.WriteString(placeholder)
+		last = end
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+// precedingWord returns the folded word before the token starting at start.
+func precedingWord(tokens []Token, start int) string {
+	for i := len(tokens) - 1; i >= 0; i-- {
+		if tokens[i].Start < start {
+			return tokens[i].Folded
+		}
+	}
+	return ""
+}
+
+// redactDistinctive replaces numbers and distinctive nouns.
+func redactDistinctive(s string, corpus *Corpus) string {
+	var b strings.Builder
+	last := 0
+	for _, tok := range Tokens(s) {
+		if IsPlaceholder(s, tok) {
+			continue
+		}
+		placeholder := ""
+		switch {
+		case isNumber(tok.Folded):
+			placeholder = Number
+		case distinctiveNoun(tok.Folded, corpus):
+			placeholder = Thing
+		default:
+			continue
+		}
+		b.WriteString(s[last:tok.Start])
+		b.WriteString(placeholder)
+		last = tok.End
+	}
+	b.WriteString(s[last:])
+	return b.String()
+}
+
+func isNumber(word string) bool {
+	if numberWords[word] {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(word)
+	return unicode.IsDigit(first)
+}
+
+func distinctiveNoun(word string, corpus *Corpus) bool {
+	if stopWords[word] || utf8.RuneCountInString(word) < 5 || !corpus.Distinctive(word) {
+		return false
+	}
+	for _, suffix := range nonNounSuffixes {
+		if strings.HasSuffix(word, suffix) {
+			return false
+		}
+	}
+	return true
+}
+
+// abbreviations end in a period without ending the sentence.
+var abbreviations = map[string]bool{
+	"mr": true, "mrs": true, "ms": true, "dr": true, "st": true, "jr": true, "sr": true, "vs": true, "lt": true, "col": true, "gen": true,
+}
+
+// FirstSentence returns the first sentence of s.
+func FirstSentence(s string) string {
+	if sentences := Sentences(s); len(sentences) > 0 {
+		return sentences[0]
+	}
+	return ""
+}
+
+// Sentences splits s into its sentences, with surrounding space trimmed.
+func Sentences(s string) []string {
+	var sentences []string
+	start := 0
+	for i, r := range s {
+		if i < start || (r != '.' && r != '!' && r != '?') {
+			continue
+		}
+		end := i + 1
+		for end < len(s) && strings.IndexByte("\"')", s[end]) >= 0 {
+			end++
+		}
+		if end < len(s) && s[end] != ' ' && s[end] != '\n' {
+			continue
+		}
+		if r == '.' {
+			word := s[start:i]
+			if j := strings.LastIndexFunc(word, func(r rune) bool { return !isWordRune(r) }); j >= 0 {
+				word = word[j+1:]
+			}
+			// "Mr." and initials like the "J." in "Michael J. Fox".
+			if abbreviations[Fold(word)] || utf8.RuneCountInString(word) == 1 {
+				continue
+			}
+		}
+		if sentence := strings.TrimSpace(s[start:end]); sentence != "" {
+			sentences = append(sentences, sentence)
+		}
+		start = end
+	}
+	if rest := strings.TrimSpace(s[start:]); rest != "" {
+		sentences = append(sentences, rest)
+	}
+	return sentences
+}
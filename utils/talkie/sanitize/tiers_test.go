@@ -0,0 +1,64 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func TestFirstSentence(t *testing.T) {
+	for in, want := range map[string]string{
+		"One sentence only": "One sentence only",
+		"Marty McFly meets Dr. Brown. Then time travel.":    "Marty McFly meets Dr. Brown.",
+		"Michael J. Fox stars! Everyone cheers.":            "Michael J. Fox stars!",
+		`He asks "Why?" She answers.`:                       `He asks "Why?"`,
+		"The agency known as S.H.I.E.L.D. recruits a team.": "The agency known as S.H.I.E.L.D. recruits a team.",
+		"Costs 3.5 million. Or more.":                       "Costs 3.5 million.",
+	} {
+		if got := FirstSentence(in); got != want {
+			t.Errorf("FirstSentence(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRedactProperNouns(t *testing.T) {
+	for in, want := range map[string]string{
+		"Xander Cage is sent to New York by the US Government.":      "[Name] is sent to [Place] by the [Name].",
+		"Rookie cops Judy Hopps and Nick Wilde team up. Judy leads.": "Rookie cops [Name] and [Name] team up. [Name] leads.",
+		"The war reaches Jake Sully. Jake's family must fight.":      "The war reaches [Name]. [Name] family must fight.",
+		"In the end, [Protagonist] wins.":                            "In the end, [Protagonist] wins.",
+	} {
+		if got := redactProperNouns(in); got != want {
+			t.Errorf("redactProperNouns(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestOverviews(t *testing.T) {
+	m := model.Movie{
+		Title:            "Jaws",
+		OriginalOverview: "When a shark attacks Amity Island, police chief Martin Brody calls in a harpoon. Jaws terrorizes 3 swimmers.",
+		Actors:           []model.MovieActor{{Name: "Roy Scheider", Character: "Martin Brody"}},
+	}
+	corpus := NewCorpus([]string{
+		m.OriginalOverview,
+		"When a police chief calls in help, the town panics and attacks.",
+		"A shark attacks a police chief and two swimmers on an island.",
+	})
+
+	got := Overviews(m, corpus)
+	want := map[string]string{
+		Easy:   "When a shark attacks Amity Island, police chief Martin Brody calls in a harpoon. [Protagonist] terrorizes 3 swimmers.",
+		Medium: "When a shark attacks [Name], police chief [Protagonist] calls in a harpoon. [Protagonist] terrorizes 3 swimmers.",
+		Hard:   "When a shark attacks [Name], police chief [Protagonist] calls in a [Thing].",
+	}
+	for _, tier := range []string{Easy, Medium, Hard} {
+		if got[tier] != want[tier] {
+			t.Errorf("%s:\n got %q\nwant %q", tier, got[tier], want[tier])
+		}
+	}
+
+	if got := redactDistinctive("The shark terrorizes 3 swimmers and two harpoons.", corpus); got != "The shark terrorizes [Number] swimmers and [Number] [Thing]." {
+		t.Errorf("redactDistinctive = %q", got)
+	}
+}
@@ -0,0 +1,95 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+// FileName is the config file's name in the utils directory.
+const FileName = "schedule.json"
+
+// ConstraintConfig sets the scheduling constraints. A zero value disables
+// its constraint.
+type ConstraintConfig struct {
+	RepeatGapDays     int `json:"repeat_gap_days,omitempty"`
+	CollectionGapDays int `json:"collection_gap_days,omitempty"`
+	DirectorGapDays   int `json:"director_gap_days,omitempty"`
+	GenreGapDays      int `json:"genre_gap_days,omitempty"`
+	DecadeMaxPerWeek  int `json:"decade_max_per_week,omitempty"`
+}
+
+// Config is the on-disk schedule configuration.
+type Config struct {
+	Constraints ConstraintConfig `json:"constraints"`
+	Difficulty  DifficultyConfig `json:"difficulty"`
+}
+
+// Load reads a schedule config from path.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read schedule config %s: %w", path, err)
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("could not parse schedule config %s: %w", path, err)
+	}
+	if _, err := cfg.Difficulty.bands(); err != nil {
+		return nil, fmt.Errorf("invalid schedule config %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+// ConstraintsFor returns the enabled constraints for scheduling movies, most
+// important first: the repeat gap, the weekday difficulty bands (scored
+// over movies), then the rest of c.Constraints. The planner relaxes them
+// from the end of the list. It also returns the difficulty scores.
+func (c *Config) ConstraintsFor(movies []model.Movie) ([]Constraint, map[int]float64) {
+	scores := Difficulties(movies, c.Difficulty.Weights)
+	constraints := c.Constraints.Constraints()
+	bands, _ := c.Difficulty.bands()
+	if len(bands) == 0 {
+		return constraints, scores
+	}
+	band := DifficultyBand{Bands: bands, Scores: scores}
+	if len(constraints) > 0 && c.Constraints.RepeatGapDays > 0 {
+		return append([]Constraint{constraints[0], band}, constraints[1:]...), scores
+	}
+	return append([]Constraint{band}, constraints...), scores
+}
+
+// Constraints returns the enabled constraints, most important first. The
+// planner relaxes them from the end of the list.
+func (c ConstraintConfig) Constraints() []Constraint {
+	var constraints []Constraint
+	if c.RepeatGapDays > 0 {
+		constraints = append(constraints, NoRepeat(c.RepeatGapDays))
+	}
+	if c.CollectionGapDays > 0 {
+		constraints = append(constraints, CollectionGap(c.CollectionGapDays))
+	}
+	if c.DirectorGapDays > 0 {
+		constraints = append(constraints, DirectorGap(c.DirectorGapDays))
+	}
+	if c.GenreGapDays > 0 {
+		constraints = append(constraints, GenreGap(c.GenreGapDays))
+	}
+	if c.DecadeMaxPerWeek > 0 {
+		constraints = append(constraints, DecadeSpread{MaxPerWeek: c.DecadeMaxPerWeek})
+	}
+	return constraints
+}
+
+// lookback returns how many days of earlier games the constraints look at.
+func (c ConstraintConfig) lookback() int {
+	days := 7 // DecadeSpread's week
+	for _, gap := range []int{c.RepeatGapDays, c.CollectionGapDays, c.DirectorGapDays, c.GenreGapDays} {
+		if gap > days {
+			days = gap
+		}
+	}
+	return days
+}
@@ -0,0 +1,121 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+// Constraint decides whether a movie may be scheduled on a day.
+type Constraint interface {
+	// Name identifies the constraint in relaxation reports.
+	Name() string
+	// Allow reports whether m may be scheduled on day, given the games
+	// scheduled so far in date order. Pinned games may lie after day.
+	Allow(games []Game, day time.Time, m model.Movie) bool
+}
+
+// window returns the games dated in [from, to).
+func window(games []Game, from, to time.Time) []Game {
+	lo := sort.Search(len(games), func(i int) bool { return !games[i].Date.Before(from) })
+	hi := sort.Search(len(games), func(i int) bool { return !games[i].Date.Before(to) })
+	return games[lo:hi]
+}
+
+// Gap keeps movies that share a key at least Days days apart. Movies
+// without a key are never held back.
+type Gap struct {
+	Label string
+	Days  int
+	Key   func(model.Movie) string
+}
+
+// NoRepeat keeps the same movie from coming back within days.
+func NoRepeat(days int) Gap {
+	return Gap{Label: "repeat", Days: days, Key: func(m model.Movie) string { return strconv.Itoa(m.ID) }}
+}
+
+// CollectionGap spaces out movies of the same TMDB collection, so a sequel
+// doesn't land right after its predecessor.
+func CollectionGap(days int) Gap {
+	return Gap{Label: "collection", Days: days, Key: func(m model.Movie) string {
+		if m.Collection == nil {
+			return ""
+		}
+		return strconv.Itoa(m.Collection.ID)
+	}}
+}
+
+// DirectorGap spaces out movies by the same director.
+func DirectorGap(days int) Gap {
+	return Gap{Label: "director", Days: days, Key: func(m model.Movie) string {
+		if m.Director.ID != 0 {
+			return strconv.Itoa(m.Director.ID)
+		}
+		return m.Director.Name
+	}}
+}
+
+// GenreGap spaces out movies with the same primary (first listed) genre.
+func GenreGap(days int) Gap {
+	return Gap{Label: "genre", Days: days, Key: func(m model.Movie) string {
+		if len(m.Genres) == 0 {
+			return ""
+		}
+		return m.Genres[0].Name
+	}}
+}
+
+func (g Gap) Name() string {
+	return fmt.Sprintf("%s gap %dd", g.Label, g.Days)
+}
+
+func (g Gap) Allow(games []Game, day time.Time, m model.Movie) bool {
+	key := g.Key(m)
+	if key == "" {
+		return true
+	}
+	for _, other := range window(games, day.AddDate(0, 0, 1-g.Days), day.AddDate(0, 0, g.Days)) {
+		if g.Key(other.Movie) == key {
+			return false
+		}
+	}
+	return true
+}
+
+// DecadeSpread allows at most MaxPerWeek movies from the same decade in a
+// week, counted from Monday.
+type DecadeSpread struct {
+	MaxPerWeek int
+}
+
+func (d DecadeSpread) Name() string {
+	return fmt.Sprintf("decade spread %d/week", d.MaxPerWeek)
+}
+
+func (d DecadeSpread) Allow(games []Game, day time.Time, m model.Movie) bool {
+	decade := Decade(m)
+	if decade == "" {
+		return true
+	}
+	monday := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	count := 0
+	for _, other := range window(games, monday, monday.AddDate(0, 0, 7)) {
+		if Decade(other.Movie) == decade {
+			count++
+		}
+	}
+	return count < d.MaxPerWeek
+}
+
+// Decade returns the decade of m's release, such as "1990s", or "" if the
+// release date is unknown.
+func Decade(m model.Movie) string {
+	if len(m.ReleaseDate) < 4 {
+		return ""
+	}
+	return m.ReleaseDate[:3] + "0s"
+}
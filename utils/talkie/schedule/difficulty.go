@@ -0,0 +1,149 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/talkie/audit"
+)
+
+// DifficultyWeights sets how much each signal contributes to a movie's
+// difficulty. Popular, much-voted, recent and leaky movies are easier.
+type DifficultyWeights struct {
+	Popularity float64 `json:"popularity"`
+	VoteCount  float64 `json:"vote_count"`
+	Age        float64 `json:"age"`
+	Leakiness  float64 `json:"leakiness"`
+}
+
+// Band is an inclusive range of difficulty scores.
+type Band struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Contains reports whether score lies in b.
+func (b Band) Contains(score float64) bool {
+	return score >= b.Min && score <= b.Max
+}
+
+// DifficultyConfig sets the difficulty weights and the target band for
+// each weekday, keyed by lowercase English day name ("monday").
+type DifficultyConfig struct {
+	Weights  DifficultyWeights `json:"weights"`
+	Weekdays map[string]Band   `json:"weekdays,omitempty"`
+}
+
+// bands returns the weekday bands indexed by time.Weekday.
+func (c DifficultyConfig) bands() (map[time.Weekday]Band, error) {
+	bands := make(map[time.Weekday]Band, len(c.Weekdays))
+	for name, band := range c.Weekdays {
+		day, ok := weekdays[strings.ToLower(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown weekday %q", name)
+		}
+		if band.Min < 0 || band.Max > 1 || band.Min > band.Max {
+			return nil, fmt.Errorf("invalid difficulty band for %s: %v-%v", name, band.Min, band.Max)
+		}
+		bands[day] = band
+	}
+	return bands, nil
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday,
+}
+
+// Difficulties scores every movie from 0 (easiest) to 1 (hardest). Each
+// signal is ranked across the movies, the ranks are combined by w, and the
+// result is ranked again so scores spread evenly over the range. Leakiness
+// is the audit's leak score of the sanitized overview and tagline.
+func Difficulties(movies []model.Movie, w DifficultyWeights) map[int]float64 {
+	leaks := make(map[int]float64)
+	for _, m := range audit.Audit(movies).Movies {
+		leaks[m.ID] = m.Score
+	}
+
+	n := len(movies)
+	popularity, votes, released, leakiness := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	for i, m := range movies {
+		popularity[i] = m.Popularity
+		votes[i] = float64(m.VoteCount)
+		released[i] = float64(releaseDay(m))
+		leakiness[i] = leaks[m.ID]
+	}
+	popularity, votes, released, leakiness = ranks(popularity), ranks(votes), ranks(released), ranks(leakiness)
+
+	total := w.Popularity + w.VoteCount + w.Age + w.Leakiness
+	raw := make([]float64, n)
+	for i := range movies {
+		if total == 0 {
+			continue
+		}
+		raw[i] = (w.Popularity*(1-popularity[i]) + w.VoteCount*(1-votes[i]) + w.Age*(1-released[i]) + w.Leakiness*(1-leakiness[i])) / total
+	}
+
+	scores := make(map[int]float64, n)
+	for i, r := range ranks(raw) {
+		scores[movies[i].ID] = r
+	}
+	return scores
+}
+
+// releaseDay returns m's release date as days since 1900, or 0 if unknown,
+// so undated movies count as the oldest.
+func releaseDay(m model.Movie) int {
+	t, err := time.Parse("2006-01-02", m.ReleaseDate)
+	if err != nil {
+		return 0
+	}
+	return int(t.Sub(time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)).Hours() / 24)
+}
+
+// ranks maps each value to its rank among values, scaled to [0, 1]. Ties
+// share their mean rank.
+func ranks(values []float64) []float64 {
+	n := len(values)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+
+	out := make([]float64, n)
+	for lo := 0; lo < n; {
+		hi := lo + 1
+		for hi < n && values[order[hi]] == values[order[lo]] {
+			hi++
+		}
+		rank := 0.5
+		if n > 1 {
+			rank = float64(lo+hi-1) / 2 / float64(n-1)
+		}
+		for _, i := range order[lo:hi] {
+			out[i] = rank
+		}
+		lo = hi
+	}
+	return out
+}
+
+// DifficultyBand keeps each weekday's movie within its difficulty band.
+// Days without a band are unconstrained.
+type DifficultyBand struct {
+	Bands  map[time.Weekday]Band
+	Scores map[int]float64
+}
+
+func (d DifficultyBand) Name() string {
+	return "difficulty band"
+}
+
+func (d DifficultyBand) Allow(games []Game, day time.Time, m model.Movie) bool {
+	band, ok := d.Bands[day.Weekday()]
+	return !ok || band.Contains(d.Scores[m.ID])
+}
@@ -0,0 +1,76 @@
+package schedule
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func TestRanks(t *testing.T) {
+	got := ranks([]float64{30, 10, 20, 20, 40})
+	want := []float64{0.75, 0, 0.375, 0.375, 1}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ranks = %v, want %v", got, want)
+	}
+}
+
+func TestDifficulties(t *testing.T) {
+	movies := []model.Movie{
+		{ID: 1, Title: "Blockbuster", Popularity: 200, VoteCount: 20000, ReleaseDate: "2019-04-24", Overview: "A hero saves the day."},
+		{ID: 2, Title: "Cult Classic", Popularity: 5, VoteCount: 300, ReleaseDate: "1962-01-01", Overview: "A drifter arrives in a town."},
+		{ID: 3, Title: "Middling", Popularity: 40, VoteCount: 3000, ReleaseDate: "1995-06-30", Overview: "A family moves house."},
+		// Same numbers as Middling, but the overview leaks the title.
+		{ID: 4, Title: "Leaky", Popularity: 40, VoteCount: 3000, ReleaseDate: "1995-06-30", Overview: "Leaky the clown moves house."},
+	}
+	scores := Difficulties(movies, DifficultyWeights{Popularity: 1, VoteCount: 1, Age: 1, Leakiness: 1})
+	if !(scores[1] < scores[4] && scores[4] < scores[3] && scores[3] < scores[2]) {
+		t.Errorf("scores = %v, want Blockbuster < Leaky < Middling < Cult Classic", scores)
+	}
+	if scores[1] != 0 || scores[2] != 1 {
+		t.Errorf("scores = %v, want the easiest at 0 and the hardest at 1", scores)
+	}
+}
+
+func TestConstraintsFor(t *testing.T) {
+	cfg, err := Load(filepath.Join("..", "..", FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	constraints, _ := cfg.ConstraintsFor([]model.Movie{{ID: 1}})
+	var names []string
+	for _, c := range constraints {
+		names = append(names, c.Name())
+	}
+	if len(names) != 6 || names[0] != NoRepeat(cfg.Constraints.RepeatGapDays).Name() || names[1] != "difficulty band" {
+		t.Errorf("constraints = %v, want the repeat gap, then the difficulty band, then the rest", names)
+	}
+}
+
+func TestLoadRejectsBadBands(t *testing.T) {
+	for _, content := range []string{
+		`{"difficulty": {"weekdays": {"funday": {"min": 0, "max": 1}}}}`,
+		`{"difficulty": {"weekdays": {"monday": {"min": 0.6, "max": 0.2}}}}`,
+	} {
+		path := filepath.Join(t.TempDir(), FileName)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil {
+			t.Errorf("Load accepted %s", content)
+		}
+	}
+}
+
+func TestDifficultyBand(t *testing.T) {
+	c := DifficultyBand{Bands: map[time.Weekday]Band{time.Monday: {Min: 0, Max: 0.3}}, Scores: map[int]float64{1: 0.2, 2: 0.9}}
+	if !c.Allow(nil, start, model.Movie{ID: 1}) || c.Allow(nil, start, model.Movie{ID: 2}) {
+		t.Error("Monday band not applied")
+	}
+	if !c.Allow(nil, start.AddDate(0, 0, 1), model.Movie{ID: 2}) {
+		t.Error("Tuesday has no band but rejected a movie")
+	}
+}
@@ -0,0 +1,199 @@
+package schedule
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+// AlgorithmVersion identifies the planning algorithm. Bump it with any
+// change that plans a different schedule for the same seed, movies and
+// config, so recorded seeds are never replayed against the wrong algorithm.
+const AlgorithmVersion = 2
+
+// Game is one movie scheduled on a date. Rule names the rule that pinned
+// it, if any.
+type Game struct {
+	Date  time.Time
+	Movie model.Movie
+	Rule  string
+}
+
+// Relaxation records a day on which no movie met every constraint, and the
+// constraints the planner dropped to fill it.
+type Relaxation struct {
+	Date    time.Time
+	MovieID int
+	Relaxed []string
+}
+
+// Unpinned records a day a rule selected but no movie matching it was
+// left, so it was filled like any other day.
+type Unpinned struct {
+	Date time.Time
+	Rule string
+}
+
+// Plan is a computed schedule.
+type Plan struct {
+	Games       []Game
+	Relaxations []Relaxation
+	Unpinned    []Unpinned
+}
+
+// NewPlan schedules one movie a day for days days from start. history
+// holds the games already scheduled before start, in date order, so the
+// constraints carry across runs.
+//
+// Days selected by rules are planned first, each pinned to a movie
+// matching the first rule that selects it and not pinned on another day.
+// The remaining days are then filled in date order from the movies not
+// pinned in this plan. Movies are tried least recently scheduled first, in
+// a random order otherwise; each day takes the first movie that meets
+// every constraint, including those against pinned games on later days.
+// When none does, constraints are dropped from the end of the list until
+// one fits, and the relaxation is recorded.
+func NewPlan(movies []model.Movie, history []Game, start time.Time, days int, constraints []Constraint, rules *Rules, r *rand.Rand) (*Plan, error) {
+	if len(movies) == 0 {
+		return nil, errors.New("no movies to schedule")
+	}
+
+	// Sort first so the plan depends only on the movie set and r, not on
+	// the order the movies were read in.
+	queue := append([]model.Movie(nil), movies...)
+	sort.Slice(queue, func(i, j int) bool { return queue[i].ID < queue[j].ID })
+	r.Shuffle(len(queue), func(i, j int) { queue[i], queue[j] = queue[j], queue[i] })
+	// Movies scheduled before start go to the back, most recent last.
+	for _, g := range history {
+		for i, m := range queue {
+			if m.ID == g.Movie.ID {
+				queue = moveToBack(queue, i)
+				break
+			}
+		}
+	}
+
+	p := &planner{queue: queue, games: append([]Game(nil), history...), constraints: constraints, plan: &Plan{}}
+
+	pinned := make([]bool, days)
+	reserved := make(map[int]bool)
+	for d := 0; d < days; d++ {
+		day := start.AddDate(0, 0, d)
+		rule := rules.rule(day)
+		if rule == nil {
+			continue
+		}
+		// A movie is pinned at most once per plan.
+		matches := func(m model.Movie) bool { return !reserved[m.ID] && rule.Matches(m) }
+		if m, ok := p.place(day, matches, rule.Name); ok {
+			pinned[d], reserved[m.ID] = true, true
+		} else {
+			p.plan.Unpinned = append(p.plan.Unpinned, Unpinned{Date: day, Rule: rule.Name})
+		}
+	}
+
+	unreserved := func(m model.Movie) bool { return !reserved[m.ID] }
+	all := func(model.Movie) bool { return true }
+	for d := 0; d < days; d++ {
+		if pinned[d] {
+			continue
+		}
+		day := start.AddDate(0, 0, d)
+		if _, ok := p.place(day, unreserved, ""); !ok {
+			// Every movie is pinned somewhere in the plan.
+			p.place(day, all, "")
+		}
+	}
+
+	p.plan.Games = p.games[len(history):]
+	sort.SliceStable(p.plan.Relaxations, func(i, j int) bool {
+		return p.plan.Relaxations[i].Date.Before(p.plan.Relaxations[j].Date)
+	})
+	return p.plan, nil
+}
+
+// planner holds the state of a plan being built.
+type planner struct {
+	// queue holds the movies least recently scheduled first.
+	queue []model.Movie
+	// games holds the history and the games placed so far, in date order.
+	games       []Game
+	constraints []Constraint
+	plan        *Plan
+}
+
+// place schedules on day the first movie in the queue that is eligible and
+// meets the constraints, relaxing them as needed. It reports false if no
+// movie is eligible.
+func (p *planner) place(day time.Time, eligible func(model.Movie) bool, rule string) (model.Movie, bool) {
+	pick, kept := -1, len(p.constraints)
+	for ; kept >= 0; kept-- {
+		if pick = p.first(day, eligible, p.constraints[:kept]); pick >= 0 {
+			break
+		}
+	}
+	if pick < 0 {
+		return model.Movie{}, false
+	}
+	m := p.queue[pick]
+	if kept < len(p.constraints) {
+		var relaxed []string
+		for _, c := range p.constraints[kept:] {
+			if !c.Allow(p.games, day, m) {
+				relaxed = append(relaxed, c.Name())
+			}
+		}
+		p.plan.Relaxations = append(p.plan.Relaxations, Relaxation{Date: day, MovieID: m.ID, Relaxed: relaxed})
+	}
+
+	i := sort.Search(len(p.games), func(i int) bool { return p.games[i].Date.After(day) })
+	p.games = append(p.games, Game{})
+	copy(p.games[i+1:], p.games[i:])
+	p.games[i] = Game{Date: day, Movie: m, Rule: rule}
+
+	p.queue = moveToBack(p.queue, pick)
+	return m, true
+}
+
+// first returns the index of the first eligible movie in the queue that
+// meets every constraint on day, or -1.
+func (p *planner) first(day time.Time, eligible func(model.Movie) bool, constraints []Constraint) int {
+	for i, m := range p.queue {
+		if eligible(m) && fits(p.games, day, m, constraints) {
+			return i
+		}
+	}
+	return -1
+}
+
+func fits(games []Game, day time.Time, m model.Movie, constraints []Constraint) bool {
+	for _, c := range constraints {
+		if !c.Allow(games, day, m) {
+			return false
+		}
+	}
+	return true
+}
+
+// moveToBack moves queue[i] to the end of queue, keeping the order of the
+// rest.
+func moveToBack(queue []model.Movie, i int) []model.Movie {
+	m := queue[i]
+	copy(queue[i:], queue[i+1:])
+	queue[len(queue)-1] = m
+	return queue
+}
+
+// Relaxed counts the relaxations by constraint name.
+func (p *Plan) Relaxed() map[string]int {
+	counts := make(map[string]int)
+	for _, r := range p.Relaxations {
+		for _, name := range r.Relaxed {
+			counts[name]++
+		}
+	}
+	return counts
+}
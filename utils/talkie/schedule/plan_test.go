@@ -0,0 +1,156 @@
+package schedule
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+var start = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC) // a Monday
+
+func movie(id int, genre, director, released string) model.Movie {
+	return model.Movie{
+		ID:          id,
+		Genres:      []model.Genre{{Name: genre}},
+		Director:    model.MovieDirector{Name: director},
+		ReleaseDate: released,
+	}
+}
+
+func TestSharedConfig(t *testing.T) {
+	cfg, err := Load(filepath.Join("..", "..", FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Constraints.Constraints()) != 5 {
+		t.Errorf("got constraints %v, want all five enabled", cfg.Constraints.Constraints())
+	}
+}
+
+func TestNewPlanSpacesGenres(t *testing.T) {
+	var movies []model.Movie
+	for i := 1; i <= 6; i++ {
+		genre := "Horror"
+		if i > 3 {
+			genre = "Comedy"
+		}
+		movies = append(movies, movie(i, genre, "", ""))
+	}
+	for seed := int64(0); seed < 20; seed++ {
+		plan, err := NewPlan(movies, nil, start, 6, []Constraint{GenreGap(2)}, nil, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := 1; i < len(plan.Games); i++ {
+			if plan.Games[i].Movie.Genres[0] == plan.Games[i-1].Movie.Genres[0] {
+				t.Fatalf("seed %d: same genre on consecutive days %d and %d", seed, i-1, i)
+			}
+		}
+		if len(plan.Relaxations) != 0 {
+			t.Errorf("seed %d: unexpected relaxations %v", seed, plan.Relaxations)
+		}
+	}
+}
+
+func TestNewPlanRelaxes(t *testing.T) {
+	movies := []model.Movie{movie(1, "Drama", "Nolan", "2010"), movie(2, "Drama", "Nolan", "2014"), movie(3, "Drama", "Nolan", "2020")}
+	plan, err := NewPlan(movies, nil, start, 3, []Constraint{NoRepeat(30), DirectorGap(7)}, nil, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Relaxations) != 2 {
+		t.Fatalf("got relaxations %v, want the last two days", plan.Relaxations)
+	}
+	for _, r := range plan.Relaxations {
+		if !reflect.DeepEqual(r.Relaxed, []string{"director gap 7d"}) {
+			t.Errorf("%s relaxed %v, want only the director gap", r.Date.Format("2006-01-02"), r.Relaxed)
+		}
+	}
+	if got := plan.Relaxed(); got["director gap 7d"] != 2 {
+		t.Errorf("Relaxed() = %v", got)
+	}
+	seen := make(map[int]bool)
+	for _, g := range plan.Games {
+		if seen[g.Movie.ID] {
+			t.Errorf("movie %d repeated although the repeat gap was never relaxed", g.Movie.ID)
+		}
+		seen[g.Movie.ID] = true
+	}
+}
+
+func TestNewPlanHistory(t *testing.T) {
+	movies := []model.Movie{movie(1, "Drama", "A", ""), movie(2, "Drama", "B", "")}
+	history := []Game{{Date: start.AddDate(0, 0, -1), Movie: movies[0]}}
+	for seed := int64(0); seed < 10; seed++ {
+		plan, err := NewPlan(movies, history, start, 2, nil, nil, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ids := []int{plan.Games[0].Movie.ID, plan.Games[1].Movie.ID}; !reflect.DeepEqual(ids, []int{2, 1}) {
+			t.Errorf("seed %d: scheduled %v, want the movie from history last", seed, ids)
+		}
+	}
+}
+
+func TestDecadeSpread(t *testing.T) {
+	c := DecadeSpread{MaxPerWeek: 2}
+	nineties := movie(9, "Drama", "", "1994-09-23")
+	history := []Game{
+		{Date: start.AddDate(0, 0, -1), Movie: movie(1, "Drama", "", "1999-03-31")}, // the Sunday before
+		{Date: start, Movie: movie(2, "Drama", "", "1991-02-14")},
+		{Date: start.AddDate(0, 0, 1), Movie: movie(3, "Drama", "", "2001-12-19")},
+	}
+	if !c.Allow(history, start.AddDate(0, 0, 2), nineties) {
+		t.Error("second 1990s movie of the week was rejected")
+	}
+	history = append(history, Game{Date: start.AddDate(0, 0, 2), Movie: movie(4, "Drama", "", "1997-12-19")})
+	if c.Allow(history, start.AddDate(0, 0, 3), nineties) {
+		t.Error("third 1990s movie of the week was allowed")
+	}
+	if !c.Allow(history, start.AddDate(0, 0, 7), nineties) {
+		t.Error("1990s movie on the next Monday was rejected")
+	}
+}
+
+func TestNewPlanDeterministic(t *testing.T) {
+	var movies []model.Movie
+	for i := 1; i <= 40; i++ {
+		m := movie(i, []string{"Drama", "Horror", "Comedy"}[i%3], []string{"A", "B", "C", "D", "E"}[i%5], []string{"1975-01-01", "1994-06-01", "2012-03-01"}[i%3])
+		m.Popularity, m.VoteCount = float64(i*7%40), i*13%40*100
+		movies = append(movies, m)
+	}
+	cfg, err := Load(filepath.Join("..", "..", FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := func(movies []model.Movie, seed int64) []byte {
+		constraints, _ := cfg.ConstraintsFor(movies)
+		p, err := NewPlan(movies, nil, start, 60, constraints, nil, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	reversed := make([]model.Movie, len(movies))
+	for i, m := range movies {
+		reversed[len(movies)-1-i] = m
+	}
+	if a, b := plan(movies, 42), plan(reversed, 42); !bytes.Equal(a, b) {
+		t.Error("same seed and movie set gave different schedules")
+	}
+	if a, b := plan(movies, 42), plan(movies, 43); bytes.Equal(a, b) {
+		t.Error("different seeds gave the same schedule")
+	}
+}
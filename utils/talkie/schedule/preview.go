@@ -0,0 +1,170 @@
+package schedule
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Preview formats.
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+	FormatICS  = "ics"
+)
+
+// Entry is one scheduled game as shown in a preview.
+type Entry struct {
+	Date       string   `json:"date"`
+	MovieID    int      `json:"movie_id"`
+	Title      string   `json:"title"`
+	Genres     []string `json:"genres"`
+	Director   string   `json:"director"`
+	Difficulty float64  `json:"difficulty"`
+	Rule       string   `json:"rule,omitempty"`
+	Relaxed    []string `json:"relaxed,omitempty"`
+}
+
+// Preview is a planned schedule laid out for review before anything is
+// written to Firestore.
+type Preview struct {
+	Seed             int64   `json:"seed"`
+	AlgorithmVersion int     `json:"algorithm_version"`
+	Entries          []Entry `json:"entries"`
+}
+
+// NewPreview lays out plan with each movie's difficulty score.
+func NewPreview(plan *Plan, scores map[int]float64, seed int64) *Preview {
+	relaxed := make(map[time.Time][]string, len(plan.Relaxations))
+	for _, r := range plan.Relaxations {
+		relaxed[r.Date] = r.Relaxed
+	}
+	p := &Preview{Seed: seed, AlgorithmVersion: AlgorithmVersion, Entries: []Entry{}}
+	for _, g := range plan.Games {
+		genres := []string{}
+		for _, genre := range g.Movie.Genres {
+			genres = append(genres, genre.Name)
+		}
+		p.Entries = append(p.Entries, Entry{
+			Date:       g.Date.Format("2006-01-02"),
+			MovieID:    g.Movie.ID,
+			Title:      g.Movie.Title,
+			Genres:     genres,
+			Director:   g.Movie.Director.Name,
+			Difficulty: math.Round(scores[g.Movie.ID]*1000) / 1000,
+			Rule:       g.Rule,
+			Relaxed:    relaxed[g.Date],
+		})
+	}
+	return p
+}
+
+// Write writes the preview in format.
+func (p *Preview) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatCSV:
+		return p.WriteCSV(w)
+	case FormatJSON:
+		return p.WriteJSON(w)
+	case FormatICS:
+		return p.WriteICS(w)
+	}
+	return fmt.Errorf("unknown preview format %q", format)
+}
+
+// WriteCSV writes one row per day.
+func (p *Preview) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"date", "weekday", "movie_id", "title", "genre", "director", "difficulty", "rule", "relaxed"})
+	for _, e := range p.Entries {
+		day, _ := time.Parse("2006-01-02", e.Date)
+		cw.Write([]string{
+			e.Date,
+			day.Weekday().String(),
+			strconv.Itoa(e.MovieID),
+			e.Title,
+			strings.Join(e.Genres, ", "),
+			e.Director,
+			strconv.FormatFloat(e.Difficulty, 'f', -1, 64),
+			e.Rule,
+			strings.Join(e.Relaxed, "; "),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the preview as indented JSON.
+func (p *Preview) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(p)
+}
+
+// WriteICS writes the preview as an iCalendar file with an all-day event
+// per game, for review in any calendar app. The output depends only on the
+// preview, so the same schedule always gives the same file.
+func (p *Preview) WriteICS(w io.Writer) error {
+	var b strings.Builder
+	line := func(s string) { b.WriteString(foldICS(s)) }
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Talkie Trivia//talkie schedule//EN")
+	line("CALSCALE:GREGORIAN")
+	line("X-WR-CALNAME:Talkie Trivia daily games")
+	for _, e := range p.Entries {
+		day, err := time.Parse("2006-01-02", e.Date)
+		if err != nil {
+			return err
+		}
+		description := fmt.Sprintf("Genre: %s\nDirector: %s\nDifficulty: %s\nTMDB ID: %d",
+			strings.Join(e.Genres, ", "), e.Director, strconv.FormatFloat(e.Difficulty, 'f', -1, 64), e.MovieID)
+		if e.Rule != "" {
+			description += "\nRule: " + e.Rule
+		}
+		if len(e.Relaxed) > 0 {
+			description += "\nRelaxed: " + strings.Join(e.Relaxed, "; ")
+		}
+		line("BEGIN:VEVENT")
+		line(fmt.Sprintf("UID:%s-%d@talkie-trivia", e.Date, e.MovieID))
+		line("DTSTAMP:" + day.Format("20060102") + "T000000Z")
+		line("DTSTART;VALUE=DATE:" + day.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeICS(e.Title))
+		line("DESCRIPTION:" + escapeICS(description))
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+
+func escapeICS(s string) string {
+	return icsEscaper.Replace(s)
+}
+
+// foldICS terminates an iCalendar content line with CRLF, folding it into
+// lines of at most 75 octets without splitting a character.
+func foldICS(s string) string {
+	var b strings.Builder
+	for limit := 75; len(s) > limit; limit = 74 {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,88 @@
+package schedule
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func testPreview() *Preview {
+	seven := movie(7, "Horror", "Ridley Scott", "1979-05-25")
+	seven.Title = "Alien"
+	seven.Genres = append(seven.Genres, model.Genre{Name: "Science Fiction"})
+	heat := movie(9, "Crime", "Michael Mann", "1995-12-15")
+	heat.Title = "Heat; or, a very long title that needs folding onto a continuation line — twice over"
+	plan := &Plan{
+		Games:       []Game{{Date: start, Movie: seven}, {Date: start.AddDate(0, 0, 1), Movie: heat}},
+		Relaxations: []Relaxation{{Date: start.AddDate(0, 0, 1), MovieID: 9, Relaxed: []string{"genre gap 2d"}}},
+	}
+	return NewPreview(plan, map[int]float64{7: 0.12345, 9: 0.9}, 42)
+}
+
+func TestPreviewCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testPreview().Write(&buf, FormatCSV); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"2026-10-19", "Monday", "7", "Alien", "Horror, Science Fiction", "Ridley Scott", "0.123", "", ""}
+	if len(rows) != 3 || !reflect.DeepEqual(rows[1], want) {
+		t.Fatalf("rows = %q, want header, %q and one more", rows, want)
+	}
+	if rows[2][8] != "genre gap 2d" {
+		t.Errorf("relaxed = %q", rows[2][8])
+	}
+}
+
+func TestPreviewJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testPreview().Write(&buf, FormatJSON); err != nil {
+		t.Fatal(err)
+	}
+	var got Preview
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Seed != 42 || got.AlgorithmVersion != AlgorithmVersion || !reflect.DeepEqual(got.Entries, testPreview().Entries) {
+		t.Errorf("round trip = %+v", got)
+	}
+}
+
+func TestPreviewICS(t *testing.T) {
+	var buf bytes.Buffer
+	if err := testPreview().Write(&buf, FormatICS); err != nil {
+		t.Fatal(err)
+	}
+	ics := buf.String()
+	lines := strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n")
+	for _, l := range lines {
+		if len(l) > 75 {
+			t.Errorf("line longer than 75 octets: %q", l)
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART;VALUE=DATE:20261019\r\nDTEND;VALUE=DATE:20261020\r\n",
+		`SUMMARY:Heat\; or\, a very long title that needs folding onto a continuation line — twice over` + "\r\n",
+		`DESCRIPTION:Genre: Horror\, Science Fiction\nDirector: Ridley Scott\nDifficulty: 0.123\nTMDB ID: 7` + "\r\n",
+		`Relaxed: genre gap 2d`,
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("calendar missing %q:\n%s", want, unfolded)
+		}
+	}
+
+	if err := testPreview().Write(&buf, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,201 @@
+package schedule
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/talkie/sanitize"
+)
+
+// RulesFileName is the rules file's name in the utils directory.
+const RulesFileName = "scheduleRules.json"
+
+// Rule pins movies matching a query to the days it selects, such as horror
+// on Fridays in October. Every given day selector must hold: Dates lists
+// fixed days as MM-DD, From and To bound an inclusive MM-DD range (which
+// may wrap around New Year), and Months (1-12) and Weekdays narrow it
+// down. A movie matches if its TMDB ID is listed in IDs, or if it has one
+// of the Genres, one of the Directors and one of the Keywords, for each
+// of those that is given. Keywords are matched as whole words against the
+// movie's TMDB keywords, title and original overview.
+type Rule struct {
+	Name string `json:"name"`
+
+	Dates    []string `json:"dates,omitempty"`
+	From     string   `json:"from,omitempty"`
+	To       string   `json:"to,omitempty"`
+	Months   []int    `json:"months,omitempty"`
+	Weekdays []string `json:"weekdays,omitempty"`
+
+	IDs       []int    `json:"ids,omitempty"`
+	Genres    []string `json:"genres,omitempty"`
+	Directors []string `json:"directors,omitempty"`
+	Keywords  []string `json:"keywords,omitempty"`
+}
+
+// Rules is the on-disk rules file. Earlier rules take precedence when
+// several select the same day.
+type Rules struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadRules reads and checks a rules file.
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read schedule rules %s: %w", path, err)
+	}
+	var rules Rules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("could not parse schedule rules %s: %w", path, err)
+	}
+	for _, r := range rules.Rules {
+		if err := r.check(); err != nil {
+			return nil, fmt.Errorf("invalid schedule rule %q in %s: %w", r.Name, path, err)
+		}
+	}
+	return &rules, nil
+}
+
+func (r Rule) check() error {
+	if len(r.Dates) == 0 && r.From == "" && r.To == "" && len(r.Months) == 0 && len(r.Weekdays) == 0 {
+		return fmt.Errorf("no days selected")
+	}
+	if len(r.IDs) == 0 && len(r.Genres) == 0 && len(r.Directors) == 0 && len(r.Keywords) == 0 {
+		return fmt.Errorf("no movies selected")
+	}
+	if (r.From == "") != (r.To == "") {
+		return fmt.Errorf("from and to must be given together")
+	}
+	for _, d := range append(append([]string(nil), r.Dates...), r.From, r.To) {
+		if _, err := time.Parse("01-02", d); d != "" && err != nil {
+			return fmt.Errorf("invalid date %q, want MM-DD", d)
+		}
+	}
+	for _, m := range r.Months {
+		if m < 1 || m > 12 {
+			return fmt.Errorf("invalid month %d", m)
+		}
+	}
+	for _, w := range r.Weekdays {
+		if _, ok := weekdays[strings.ToLower(w)]; !ok {
+			return fmt.Errorf("unknown weekday %q", w)
+		}
+	}
+	return nil
+}
+
+// Selects reports whether day is one of the rule's days.
+func (r Rule) Selects(day time.Time) bool {
+	md := day.Format("01-02")
+	if len(r.Dates) > 0 && !contains(r.Dates, md) {
+		return false
+	}
+	if r.From != "" {
+		inRange := md >= r.From && md <= r.To
+		if r.From > r.To {
+			inRange = md >= r.From || md <= r.To
+		}
+		if !inRange {
+			return false
+		}
+	}
+	if len(r.Months) > 0 {
+		found := false
+		for _, m := range r.Months {
+			found = found || time.Month(m) == day.Month()
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.Weekdays) > 0 {
+		found := false
+		for _, w := range r.Weekdays {
+			found = found || weekdays[strings.ToLower(w)] == day.Weekday()
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports whether m is one of the rule's movies.
+func (r Rule) Matches(m model.Movie) bool {
+	for _, id := range r.IDs {
+		if id == m.ID {
+			return true
+		}
+	}
+	if len(r.Genres) == 0 && len(r.Directors) == 0 && len(r.Keywords) == 0 {
+		return false
+	}
+	if len(r.Genres) > 0 {
+		found := false
+		for _, g := range m.Genres {
+			found = found || containsFold(r.Genres, g.Name)
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.Directors) > 0 && !containsFold(r.Directors, m.Director.Name) {
+		return false
+	}
+	if len(r.Keywords) > 0 {
+		text := strings.Join(append(append([]string(nil), m.Keywords...), m.Title, m.OriginalOverview), " ")
+		var folded strings.Builder
+		folded.WriteString(" ")
+		for _, tok := range sanitize.Tokens(text) {
+			folded.WriteString(tok.Folded + " ")
+		}
+		found := false
+		for _, k := range r.Keywords {
+			var kw strings.Builder
+			for _, tok := range sanitize.Tokens(k) {
+				kw.WriteString(" " + tok.Folded)
+			}
+			found = found || (kw.Len() > 0 && strings.Contains(folded.String(), kw.String()+" "))
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(list []string, s string) bool {
+	for _, v := range list {
+		if sanitize.Fold(v) == sanitize.Fold(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// rule returns the first rule that selects day, or nil.
+func (rs *Rules) rule(day time.Time) *Rule {
+	if rs == nil {
+		return nil
+	}
+	for i := range rs.Rules {
+		if rs.Rules[i].Selects(day) {
+			return &rs.Rules[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package schedule
+
+import (
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestSharedRules(t *testing.T) {
+	if _, err := LoadRules(filepath.Join("..", "..", RulesFileName)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadRulesRejectsBadRules(t *testing.T) {
+	for _, rule := range []string{
+		`{"name": "no days", "genres": ["Horror"]}`,
+		`{"name": "no movies", "dates": ["10-31"]}`,
+		`{"name": "bad date", "dates": ["31-10"], "genres": ["Horror"]}`,
+		`{"name": "open range", "from": "12-18", "genres": ["Horror"]}`,
+		`{"name": "bad weekday", "weekdays": ["caturday"], "genres": ["Horror"]}`,
+	} {
+		path := filepath.Join(t.TempDir(), RulesFileName)
+		if err := os.WriteFile(path, []byte(`{"rules": [`+rule+`]}`), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadRules(path); err == nil {
+			t.Errorf("LoadRules accepted %s", rule)
+		}
+	}
+}
+
+func TestRuleSelects(t *testing.T) {
+	horrorFridays := Rule{Months: []int{10}, Weekdays: []string{"Friday"}}
+	newYear := Rule{From: "12-30", To: "01-02"}
+	for _, tc := range []struct {
+		rule Rule
+		day  string
+		want bool
+	}{
+		{horrorFridays, "2026-10-23", true},
+		{horrorFridays, "2026-10-24", false},
+		{horrorFridays, "2026-11-06", false},
+		{Rule{Dates: []string{"07-30"}}, "2027-07-30", true},
+		{Rule{Dates: []string{"07-30"}}, "2027-07-31", false},
+		{newYear, "2026-12-31", true},
+		{newYear, "2027-01-02", true},
+		{newYear, "2027-01-03", false},
+		{newYear, "2026-12-29", false},
+	} {
+		if got := tc.rule.Selects(date(tc.day)); got != tc.want {
+			t.Errorf("%+v selects %s = %v, want %v", tc.rule, tc.day, got, tc.want)
+		}
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	die := model.Movie{ID: 562, Title: "Die Hard", Genres: []model.Genre{{Name: "Action"}}, Director: model.MovieDirector{Name: "John McTiernan"},
+		OriginalOverview: "NYPD cop John McClane's plan to reconcile with his wife at her office's Christmas party is interrupted."}
+	amelie := model.Movie{ID: 194, Title: "Amélie", Genres: []model.Genre{{Name: "Comedy"}, {Name: "Romance"}}, Director: model.MovieDirector{Name: "Jean-Pierre Jeunet"},
+		Keywords: []string{"paris", "café"}}
+	for _, tc := range []struct {
+		rule  Rule
+		movie model.Movie
+		want  bool
+	}{
+		{Rule{IDs: []int{562}}, die, true},
+		{Rule{IDs: []int{1}}, die, false},
+		{Rule{Keywords: []string{"christmas"}}, die, true},
+		{Rule{Keywords: []string{"christmas party"}}, die, true},
+		{Rule{Keywords: []string{"christ"}}, die, false},
+		{Rule{Keywords: []string{"cafe"}}, amelie, true},
+		{Rule{Genres: []string{"romance"}}, amelie, true},
+		{Rule{Genres: []string{"Romance"}, Directors: []string{"John McTiernan"}}, amelie, false},
+		{Rule{Genres: []string{"Action"}, Directors: []string{"John McTiernan"}}, die, true},
+	} {
+		if got := tc.rule.Matches(tc.movie); got != tc.want {
+			t.Errorf("%+v matches %s = %v, want %v", tc.rule, tc.movie.Title, got, tc.want)
+		}
+	}
+}
+
+func TestNewPlanPins(t *testing.T) {
+	movies := []model.Movie{
+		movie(1, "Horror", "A", ""), movie(2, "Horror", "B", ""),
+		movie(3, "Comedy", "C", ""), movie(4, "Drama", "D", ""), movie(5, "Comedy", "E", ""), movie(6, "Drama", "F", ""),
+	}
+	rules := &Rules{Rules: []Rule{
+		// Oct 23 and 30, 2026 are Fridays; the 19th is a Monday.
+		{Name: "Horror Fridays", Months: []int{10}, Weekdays: []string{"friday"}, Genres: []string{"Horror"}},
+		{Name: "Only one left", Dates: []string{"10-24", "10-25"}, IDs: []int{3}},
+	}}
+	for seed := int64(0); seed < 10; seed++ {
+		plan, err := NewPlan(movies, nil, start, 12, []Constraint{GenreGap(2)}, rules, rand.New(rand.NewSource(seed)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		byDate := make(map[string]Game)
+		count := make(map[int]int)
+		for _, g := range plan.Games {
+			byDate[g.Date.Format("2006-01-02")] = g
+			count[g.Movie.ID]++
+		}
+		for _, day := range []string{"2026-10-23", "2026-10-30"} {
+			if g := byDate[day]; g.Rule != "Horror Fridays" || g.Movie.Genres[0].Name != "Horror" {
+				t.Errorf("seed %d: %s = %d (%q), want a pinned horror movie", seed, day, g.Movie.ID, g.Rule)
+			}
+		}
+		if g := byDate["2026-10-24"]; g.Rule != "Only one left" || g.Movie.ID != 3 {
+			t.Errorf("seed %d: 2026-10-24 = %d (%q), want movie 3 pinned", seed, g.Movie.ID, g.Rule)
+		}
+		if len(plan.Unpinned) != 1 || plan.Unpinned[0].Date.Format("01-02") != "10-25" {
+			t.Errorf("seed %d: unpinned = %v, want only 10-25", seed, plan.Unpinned)
+		}
+		// The fill respects the genre gap around later pins.
+		if g := byDate["2026-10-22"]; g.Movie.Genres[0].Name == "Horror" {
+			t.Errorf("seed %d: horror movie scheduled the day before a horror Friday", seed)
+		}
+		// Pinned movies are not used again on the six ordinary days
+		// while unpinned movies are left.
+		for _, id := range []int{1, 2, 3} {
+			if count[id] != 1 {
+				t.Errorf("seed %d: pinned movie %d scheduled %d times", seed, id, count[id])
+			}
+		}
+	}
+}
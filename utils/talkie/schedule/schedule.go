@@ -0,0 +1,292 @@
+// Package schedule assigns movies to upcoming dates in the Firestore
+// dailyGames collection.
+package schedule
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+// Configuration Constants
+const (
+	DefaultDays = 365 // Target: 1 year (6-12 months requested)
+	batchSize   = 400 // Firestore transaction limit is 500
+)
+
+// Options configures a scheduling run.
+type Options struct {
+	Days   int
+	Config *Config
+	// Rules pins themed and seasonal days; nil pins none.
+	Rules *Rules
+	// Seed seeds the planner; 0 picks one from the clock. Either way it is
+	// logged and stored on every scheduled game.
+	Seed int64
+	// Start is the first day to schedule; the zero value means the day
+	// after the last scheduled game.
+	Start time.Time
+	// DryRun writes a preview of the schedule to Preview in PreviewFormat
+	// (csv, json or ics) instead of writing it to Firestore.
+	DryRun        bool
+	Preview       io.Writer
+	PreviewFormat string
+	// Movies are scheduled instead of the Firestore 'movies' collection
+	// when Run has no client. That is only allowed for a dry run, which
+	// then has no earlier games to carry constraints over from.
+	Movies []model.Movie
+}
+
+// Run schedules opts.Days games starting at opts.Start, or else the day
+// after the last scheduled game or today if none are scheduled yet, under
+// the constraints in opts.Config. A dry run may pass a nil client to plan
+// from opts.Movies alone.
+func Run(ctx context.Context, client *firestore.Client, opts Options) error {
+	if client == nil && !opts.DryRun {
+		return errors.New("scheduling needs Firestore access; only a dry run can plan from local movies")
+	}
+
+	// 1. Fetch all movies from the 'movies' collection.
+	var movies []model.Movie
+	if client == nil {
+		movies = append(movies, opts.Movies...)
+		sort.Slice(movies, func(i, j int) bool { return movies[i].ID < movies[j].ID })
+	} else {
+		slog.Info("Fetching all movies from Firestore...")
+		var err error
+		if movies, err = loadMovies(ctx, client); err != nil {
+			return err
+		}
+	}
+	if len(movies) == 0 && client == nil {
+		return errors.New("no movies to schedule")
+	}
+	if len(movies) == 0 {
+		return errors.New("no movies found in 'movies' collection; run the populate command first")
+	}
+	slog.Info("Found unique movies for scheduling", "count", len(movies))
+
+	// 2. Determine the starting date for new schedules.
+	startDate := opts.Start
+	switch {
+	case !startDate.IsZero():
+		slog.Info("Scheduling from the given start date", "start", startDate.Format("2006-01-02"))
+	case client == nil:
+		startDate = today()
+		slog.Info("No scheduled games to follow; scheduling starts from today", "start", startDate.Format("2006-01-02"))
+	default:
+		startDate = nextStart(ctx, client)
+	}
+
+	// 3. Load the games just before the start, so the constraints carry
+	// over from earlier runs.
+	var history []Game
+	if client == nil {
+		slog.Warn("Planning without earlier daily games; constraints start fresh")
+	} else {
+		var err error
+		if history, err = loadHistory(ctx, client, movies, startDate, opts.Config.Constraints.lookback()); err != nil {
+			return err
+		}
+	}
+
+	// 4. Plan the schedule under the constraints. The same seed, movies,
+	// config, start and history always give the same plan.
+	seed := opts.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	slog.Info("Planning schedule", "seed", seed, "algorithmVersion", AlgorithmVersion)
+	constraints, scores := opts.Config.ConstraintsFor(movies)
+	plan, err := NewPlan(movies, history, startDate, opts.Days, constraints, opts.Rules, rand.New(rand.NewSource(seed)))
+	if err != nil {
+		return err
+	}
+	logPlan(plan)
+
+	if opts.DryRun {
+		if err := NewPreview(plan, scores, seed).Write(opts.Preview, opts.PreviewFormat); err != nil {
+			return fmt.Errorf("failed to write schedule preview: %w", err)
+		}
+		slog.Info("Dry run: no daily games were written", "days", len(plan.Games), "seed", seed)
+		return nil
+	}
+
+	// 5. Create and commit batches of new daily games.
+	slog.Info("Scheduling games", "days", opts.Days)
+
+	batch := client.Batch()
+	dailyGamesCollection := client.Collection("dailyGames")
+
+	for i, game := range plan.Games {
+		dateID := game.Date.Format("2006-01-02")
+		docRef := dailyGamesCollection.Doc(dateID)
+
+		gameData := model.DailyGame{
+			MovieID:          game.Movie.ID,
+			Date:             game.Date,
+			Seed:             seed,
+			AlgorithmVersion: AlgorithmVersion,
+		}
+
+		// The document data structure is now type-safe via the struct.
+		batch.Set(docRef, gameData)
+
+		// Commit batch periodically to stay within transaction limits.
+		if (i+1)%batchSize == 0 || i == len(plan.Games)-1 {
+			commitCount := i + 1
+			slog.Info("Committing batch", "batch", (commitCount-1)/batchSize+1, "through", dateID)
+
+			_, err := batch.Commit(ctx)
+			if err != nil {
+				return fmt.Errorf("batch commit failed for date %s: %w", dateID, err)
+			}
+
+			// Start a new batch after successful commit, unless done
+			if i < len(plan.Games)-1 {
+				batch = client.Batch()
+			}
+		}
+	}
+
+	slog.Info("All daily games have been successfully scheduled!", "seed", seed)
+	return nil
+}
+
+// nextStart returns the day after the last scheduled game, or today if no
+// games are scheduled from today on.
+func nextStart(ctx context.Context, client *firestore.Client) time.Time {
+	today := today()
+	startDate := today
+
+	// Find the date of the last scheduled game
+	lastGameQuery := client.Collection("dailyGames").OrderBy("date", firestore.Desc).Limit(1)
+	docs, err := lastGameQuery.Documents(ctx).GetAll()
+
+	if err == nil && len(docs) > 0 {
+		lastGameData := docs[0].Data()
+		if lastGameTimestamp, ok := lastGameData["date"].(time.Time); ok {
+			// Start the new schedule one day after the latest existing schedule
+			lastScheduledDate := time.Date(lastGameTimestamp.Year(), lastGameTimestamp.Month(), lastGameTimestamp.Day(), 0, 0, 0, 0, lastGameTimestamp.Location())
+
+			if lastScheduledDate.After(today) || lastScheduledDate.Equal(today) {
+				startDate = lastScheduledDate.AddDate(0, 0, 1)
+			}
+			slog.Info("Found last scheduled game", "last", lastScheduledDate.Format("2006-01-02"), "start", startDate.Format("2006-01-02"))
+		}
+	} else if err != nil {
+		slog.Warn("Could not query for last game date; scheduling starts from today", "err", err, "start", startDate.Format("2006-01-02"))
+	} else {
+		slog.Info("No existing daily games found; scheduling starts from today", "start", startDate.Format("2006-01-02"))
+	}
+	return startDate
+}
+
+// today returns midnight today, for consistent date calculations.
+func today() time.Time {
+	now := time.Now()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+}
+
+// loadMovies reads every document in the 'movies' collection.
+func loadMovies(ctx context.Context, client *firestore.Client) ([]model.Movie, error) {
+	moviesIter := client.Collection("movies").Documents(ctx)
+	var movies []model.Movie
+	for {
+		doc, err := moviesIter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to iterate movie documents: %w", err)
+		}
+		var m model.Movie
+		if err := doc.DataTo(&m); err != nil {
+			return nil, fmt.Errorf("failed to decode movie %s: %w", doc.Ref.ID, err)
+		}
+		// The ID is stored as a string in the document path, convert it back to int.
+		if m.ID, _ = strconv.Atoi(doc.Ref.ID); m.ID > 0 {
+			movies = append(movies, m)
+		}
+	}
+	// Document order is an implementation detail; sort so a plan depends
+	// only on the movie set.
+	sort.Slice(movies, func(i, j int) bool { return movies[i].ID < movies[j].ID })
+	return movies, nil
+}
+
+// loadHistory returns the games scheduled in the days before start, in
+// date order. Games whose movie is no longer in movies are skipped.
+func loadHistory(ctx context.Context, client *firestore.Client, movies []model.Movie, start time.Time, days int) ([]Game, error) {
+	byID := make(map[int]model.Movie, len(movies))
+	for _, m := range movies {
+		byID[m.ID] = m
+	}
+	docs, err := client.Collection("dailyGames").
+		Where("date", ">=", start.AddDate(0, 0, -days)).
+		Where("date", "<", start).
+		OrderBy("date", firestore.Asc).
+		Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recent daily games: %w", err)
+	}
+	var history []Game
+	for _, doc := range docs {
+		var g model.DailyGame
+		if err := doc.DataTo(&g); err != nil {
+			return nil, fmt.Errorf("failed to decode daily game %s: %w", doc.Ref.ID, err)
+		}
+		if m, ok := byID[g.MovieID]; ok {
+			history = append(history, Game{Date: g.Date.In(start.Location()), Movie: m})
+		}
+	}
+	slog.Info("Loaded recent daily games", "count", len(history), "days", days)
+	return history, nil
+}
+
+// logPlan reports the pinned days, every day on which constraints
+// had to be relaxed, and how often each was.
+func logPlan(plan *Plan) {
+	pins := make(map[string]int)
+	for _, g := range plan.Games {
+		if g.Rule != "" {
+			pins[g.Rule]++
+		}
+	}
+	rules := make([]string, 0, len(pins))
+	for rule := range pins {
+		rules = append(rules, rule)
+	}
+	sort.Strings(rules)
+	for _, rule := range rules {
+		slog.Info("Pinned days", "rule", rule, "days", pins[rule])
+	}
+	for _, u := range plan.Unpinned {
+		slog.Warn("No movie left for rule; scheduled as a normal day", "date", u.Date.Format("2006-01-02"), "rule", u.Rule)
+	}
+	for _, r := range plan.Relaxations {
+		slog.Warn("Relaxed constraints", "date", r.Date.Format("2006-01-02"), "movie", r.MovieID, "relaxed", strings.Join(r.Relaxed, ", "))
+	}
+	counts := plan.Relaxed()
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		slog.Info("Constraint relaxed", "constraint", name, "days", counts[name])
+	}
+	slog.Info("Planned schedule", "days", len(plan.Games), "relaxedDays", len(plan.Relaxations))
+}
@@ -0,0 +1,48 @@
+package schedule
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+func TestRunDryRunWithoutFirestore(t *testing.T) {
+	cfg, err := Load(filepath.Join("..", "..", FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := Options{
+		Days:          3,
+		Config:        cfg,
+		Seed:          7,
+		Start:         start,
+		DryRun:        true,
+		PreviewFormat: FormatJSON,
+		Movies: []model.Movie{
+			movie(3, "Horror", "John Carpenter", "1978-10-25"),
+			movie(1, "Comedy", "Harold Ramis", "1993-02-12"),
+			movie(2, "Crime", "Michael Mann", "1995-12-15"),
+		},
+	}
+	var buf bytes.Buffer
+	opts.Preview = &buf
+	if err := Run(context.Background(), nil, opts); err != nil {
+		t.Fatal(err)
+	}
+	var preview Preview
+	if err := json.Unmarshal(buf.Bytes(), &preview); err != nil {
+		t.Fatalf("preview is not JSON: %v\n%s", err, buf.String())
+	}
+	if preview.Seed != 7 || len(preview.Entries) != 3 || preview.Entries[0].Date != "2026-10-19" {
+		t.Errorf("preview = %+v, want 3 games from 2026-10-19 with seed 7", preview)
+	}
+
+	opts.DryRun = false
+	if err := Run(context.Background(), nil, opts); err == nil {
+		t.Error("Run without a client wrote a schedule")
+	}
+}
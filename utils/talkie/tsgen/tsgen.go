@@ -0,0 +1,181 @@
+// Package tsgen generates TypeScript declarations from the Go data model, so
+// the app's types for the bundled files and Firestore documents follow the
+// structs that produce them.
+package tsgen
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+)
+
+// Header starts every generated file.
+const Header = "// Code generated by talkie types; DO NOT EDIT.\n"
+
+// Decl names a Go struct as a TypeScript interface.
+type Decl struct {
+	Name string
+	Type reflect.Type
+	Doc  string
+}
+
+// Movies are the declarations for src/models/movieData.d.ts: the records in
+// the Firestore 'movies' collection, basicMovies.json and moviesLite.json.
+var Movies = []Decl{
+	{"RawMovie", reflect.TypeOf(model.Movie{}), "A movie document in the Firestore 'movies' collection (popularMovies.json)."},
+	{"JsonBasicMovie", reflect.TypeOf(model.BasicMovie{}), "An entry in basicMovies.json, the answer picker index."},
+	{"LiteMovie", reflect.TypeOf(model.LiteMovie{}), "An entry in moviesLite.json, the bundled hints: director, genres, cast and year."},
+}
+
+// Generate renders decls, followed by every struct they reference, as
+// exported TypeScript interfaces. Referenced structs keep their Go names.
+func Generate(decls []Decl) ([]byte, error) {
+	g := &generator{names: make(map[reflect.Type]string)}
+	for _, d := range decls {
+		g.names[d.Type] = d.Name
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(Header)
+	for _, d := range decls {
+		if err := g.writeInterface(&buf, d.Name, d.Type, d.Doc); err != nil {
+			return nil, err
+		}
+	}
+	// Nested types are discovered while writing, so keep going until every
+	// one has been emitted.
+	for len(g.pending) > 0 {
+		t := g.pending[0]
+		g.pending = g.pending[1:]
+		if err := g.writeInterface(&buf, g.names[t], t, ""); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+type generator struct {
+	names   map[reflect.Type]string
+	pending []reflect.Type
+}
+
+func (g *generator) writeInterface(buf *bytes.Buffer, name string, t reflect.Type, doc string) error {
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("%s: %s is not a struct", name, t)
+	}
+	buf.WriteString("\n")
+	if doc != "" {
+		fmt.Fprintf(buf, "/** %s */\n", doc)
+	}
+	fmt.Fprintf(buf, "export interface %s {\n", name)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		key, omitempty, skip := jsonName(f)
+		if skip {
+			continue
+		}
+		ts, err := g.tsType(f.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", name, f.Name, err)
+		}
+		optional := ""
+		if omitempty {
+			optional = "?"
+		}
+		fmt.Fprintf(buf, "  %s%s: %s\n", quoteKey(key), optional, ts)
+	}
+	buf.WriteString("}\n")
+	return nil
+}
+
+// jsonName returns the key encoding/json uses for f and whether it is
+// omitted when empty, or skip for fields tagged "-".
+func jsonName(f reflect.StructField) (name string, omitempty, skip bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false, true
+	}
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = f.Name
+	}
+	for _, opt := range strings.Split(opts, ",") {
+		omitempty = omitempty || opt == "omitempty"
+	}
+	return name, omitempty, false
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (g *generator) tsType(t reflect.Type) (string, error) {
+	if t == timeType {
+		return "string", nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return "string", nil
+	case reflect.Bool:
+		return "boolean", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number", nil
+	case reflect.Pointer:
+		elem, err := g.tsType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return elem + " | null", nil
+	case reflect.Slice, reflect.Array:
+		elem, err := g.tsType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		if strings.Contains(elem, " ") {
+			elem = "(" + elem + ")"
+		}
+		return elem + "[]", nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String && !isInt(t.Key().Kind()) {
+			return "", fmt.Errorf("unsupported map key %s", t.Key())
+		}
+		elem, err := g.tsType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return "Record<string, " + elem + ">", nil
+	case reflect.Struct:
+		if _, ok := g.names[t]; !ok {
+			if t.Name() == "" {
+				return "", fmt.Errorf("unsupported anonymous struct")
+			}
+			g.names[t] = t.Name()
+			g.pending = append(g.pending, t)
+		}
+		return g.names[t], nil
+	case reflect.Interface:
+		return "unknown", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+func isInt(k reflect.Kind) bool {
+	return k >= reflect.Int && k <= reflect.Uint64
+}
+
+// quoteKey quotes property names that are not valid identifiers.
+func quoteKey(key string) string {
+	for i, r := range key {
+		if !(r == '_' || r == '$' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || i > 0 && '0' <= r && r <= '9') {
+			return fmt.Sprintf("%q", key)
+		}
+	}
+	return key
+}
@@ -0,0 +1,87 @@
+package tsgen
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type person struct {
+	Name   string  `json:"name"`
+	ImdbID string  `json:"imdb_id,omitempty"`
+	Score  float64 `json:"score"`
+}
+
+type record struct {
+	ID       int                 `json:"id"`
+	Lead     person              `json:"lead"`
+	Cast     []person            `json:"cast"`
+	Backup   *person             `json:"backup,omitempty"`
+	Tiers    map[string]string   `json:"tiers,omitempty"`
+	Tags     []*string           `json:"tags"`
+	Internal string              `json:"-"`
+	Renamed  bool                `json:"2fast"`
+	Counts   map[int]interface{} `json:"counts"`
+	hidden   string
+}
+
+func TestGenerate(t *testing.T) {
+	got, err := Generate([]Decl{{"Entry", reflect.TypeOf(record{}), "A record."}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Header + `
+/** A record. */
+export interface Entry {
+  id: number
+  lead: person
+  cast: person[]
+  backup?: person | null
+  tiers?: Record<string, string>
+  tags: (string | null)[]
+  "2fast": boolean
+  counts: Record<string, unknown>
+}
+
+export interface person {
+  name: string
+  imdb_id?: string
+  score: number
+}
+`
+	if string(got) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateRejectsUnsupportedTypes(t *testing.T) {
+	type bad struct {
+		C chan int `json:"c"`
+	}
+	if _, err := Generate([]Decl{{"Bad", reflect.TypeOf(bad{}), ""}}); err == nil || !strings.Contains(err.Error(), "Bad.C") {
+		t.Errorf("got error %v, want one naming Bad.C", err)
+	}
+}
+
+// TestMoviesUpToDate fails when the checked-in declarations no longer match
+// the Go model; run 'talkie types' to regenerate them.
+func TestMoviesUpToDate(t *testing.T) {
+	path := filepath.Join("..", "..", "..", "src", "models", "movieData.d.ts")
+	current, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		t.Skip("no app checkout")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	generated, err := Generate(Movies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(current, generated) {
+		t.Errorf("%s is out of date; run 'talkie types'", path)
+	}
+}
@@ -0,0 +1,240 @@
+package validate
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// SchemaVersion is the version of the schemas in schemas/ that the current
+// tools emit. Changing the shape of a data file means adding
+// schemas/<file>.v<N+1>.json and bumping this.
+const SchemaVersion = 1
+
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// Schema is the subset of JSON Schema (draft 2020-12) the data file schemas
+// use. Loading a schema with any other keyword fails, so a schema can never
+// silently rely on a check that is not enforced.
+type Schema struct {
+	SchemaURI            string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Type                 typeList           `json:"type,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *bool              `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+
+	pattern *regexp.Regexp
+}
+
+// typeList is "type", which may be a single type name or a list of them.
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var one string
+	if err := json.Unmarshal(data, &one); err == nil {
+		*t = typeList{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return err
+	}
+	*t = many
+	return nil
+}
+
+// LoadSchema returns the embedded schema for a data file at the given
+// version.
+func LoadSchema(file string, version int) (*Schema, error) {
+	name := fmt.Sprintf("schemas/%s.v%d.json", strings.TrimSuffix(file, ".json"), version)
+	data, err := schemaFiles.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("no schema version %d for %s", version, file)
+	}
+	return parseSchema(data)
+}
+
+func parseSchema(data []byte) (*Schema, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var s Schema
+	if err := decoder.Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if err := s.compile(&s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// compile checks references and compiles patterns throughout the schema.
+func (s *Schema) compile(root *Schema) error {
+	if s.Ref != "" {
+		if _, err := root.resolve(s.Ref); err != nil {
+			return err
+		}
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	for _, sub := range s.subschemas() {
+		if err := sub.compile(root); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) subschemas() []*Schema {
+	var subs []*Schema
+	for _, name := range sortedKeys(s.Defs) {
+		subs = append(subs, s.Defs[name])
+	}
+	for _, name := range sortedKeys(s.Properties) {
+		subs = append(subs, s.Properties[name])
+	}
+	subs = append(subs, s.AllOf...)
+	if s.Items != nil {
+		subs = append(subs, s.Items)
+	}
+	return subs
+}
+
+func (s *Schema) resolve(ref string) (*Schema, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok || s.Defs[name] == nil {
+		return nil, fmt.Errorf("unresolvable $ref %q", ref)
+	}
+	return s.Defs[name], nil
+}
+
+// Validate checks v, a value decoded by encoding/json, and calls report for
+// every violation with a JSON-pointer-like path.
+func (s *Schema) Validate(v interface{}, report func(path, msg string)) {
+	s.validate(s, v, "", report)
+}
+
+func (s *Schema) validate(root *Schema, v interface{}, path string, report func(path, msg string)) {
+	if s.Ref != "" {
+		target, _ := root.resolve(s.Ref)
+		target.validate(root, v, path, report)
+	}
+	for _, sub := range s.AllOf {
+		sub.validate(root, v, path, report)
+	}
+
+	if len(s.Type) > 0 && !s.Type.matches(v) {
+		report(path, fmt.Sprintf("is %s, want %s", typeName(v), strings.Join(s.Type, " or ")))
+		return
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		for _, e := range s.Enum {
+			found = found || reflect.DeepEqual(e, v)
+		}
+		if !found {
+			report(path, fmt.Sprintf("%v is not one of %v", v, s.Enum))
+		}
+	}
+
+	switch v := v.(type) {
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			report(path, fmt.Sprintf("%g is less than %g", v, *s.Minimum))
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			report(path, fmt.Sprintf("%g is greater than %g", v, *s.Maximum))
+		}
+	case string:
+		if s.MinLength != nil && len([]rune(v)) < *s.MinLength {
+			report(path, fmt.Sprintf("is shorter than %d characters", *s.MinLength))
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			report(path, fmt.Sprintf("%q does not match %s", v, s.Pattern))
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(root, item, fmt.Sprintf("%s/%d", path, i), report)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				report(path, fmt.Sprintf("missing required property %q", name))
+			}
+		}
+		for _, name := range sortedKeys(v) {
+			if prop, ok := s.Properties[name]; ok {
+				prop.validate(root, v[name], path+"/"+name, report)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				report(path, fmt.Sprintf("unexpected property %q", name))
+			}
+		}
+	}
+}
+
+func (t typeList) matches(v interface{}) bool {
+	for _, name := range t {
+		switch name {
+		case "integer":
+			if f, ok := v.(float64); ok && f == math.Trunc(f) {
+				return true
+			}
+		default:
+			if typeName(v) == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func typeName(v interface{}) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
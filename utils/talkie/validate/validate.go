@@ -0,0 +1,98 @@
+// Package validate checks the generated data files against the versioned
+// JSON Schemas in schemas/, which mirror the shapes the app's
+// MovieDataService expects, and checks the references between the files.
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+)
+
+// Files are the data files Check validates.
+var Files = []string{datafile.PopularMovies, datafile.BasicMovies, datafile.MoviesLite}
+
+// Problem is one validation failure in a data file. Path points into the
+// file, e.g. "/12/genres/0/name", and is empty for file-level problems.
+type Problem struct {
+	File    string `json:"file"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.File + ": " + p.Message
+	}
+	return p.File + ": " + p.Path + ": " + p.Message
+}
+
+// Check validates popularMovies.json, basicMovies.json and moviesLite.json in
+// dataDir against the schemas at version, then checks that IDs are unique
+// within each file and that every movie in moviesLite.json is offered in
+// basicMovies.json.
+func Check(dataDir string, version int) ([]Problem, error) {
+	var problems []Problem
+	ids := make(map[string][]int)
+	for _, file := range Files {
+		schema, err := LoadSchema(file, version)
+		if err != nil {
+			return nil, err
+		}
+
+		data, err := os.ReadFile(filepath.Join(dataDir, file))
+		if err != nil {
+			problems = append(problems, Problem{File: file, Message: err.Error()})
+			continue
+		}
+		var doc interface{}
+		if err := json.Unmarshal(data, &doc); err != nil {
+			problems = append(problems, Problem{File: file, Message: fmt.Sprintf("invalid JSON: %v", err)})
+			continue
+		}
+
+		schema.Validate(doc, func(path, msg string) {
+			problems = append(problems, Problem{File: file, Path: path, Message: msg})
+		})
+		ids[file] = movieIDs(doc)
+	}
+
+	for _, file := range Files {
+		seen := make(map[int]bool, len(ids[file]))
+		for _, id := range ids[file] {
+			if seen[id] {
+				problems = append(problems, Problem{File: file, Message: fmt.Sprintf("duplicate id %d", id)})
+			}
+			seen[id] = true
+		}
+	}
+
+	if basic, ok := ids[datafile.BasicMovies]; ok {
+		inBasic := make(map[int]bool, len(basic))
+		for _, id := range basic {
+			inBasic[id] = true
+		}
+		for _, id := range ids[datafile.MoviesLite] {
+			if !inBasic[id] {
+				problems = append(problems, Problem{File: datafile.MoviesLite, Message: fmt.Sprintf("id %d is not in %s", id, datafile.BasicMovies)})
+			}
+		}
+	}
+	return problems, nil
+}
+
+// movieIDs returns the integer "id" of every object in an array document.
+func movieIDs(doc interface{}) []int {
+	items, _ := doc.([]interface{})
+	var ids []int
+	for _, item := range items {
+		obj, _ := item.(map[string]interface{})
+		if id, ok := obj["id"].(float64); ok {
+			ids = append(ids, int(id))
+		}
+	}
+	return ids
+}
@@ -0,0 +1,107 @@
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/unrealities/talkie-trivia/utils/common/model"
+	"github.com/unrealities/talkie-trivia/utils/talkie/datafile"
+	"github.com/unrealities/talkie-trivia/utils/talkie/optimize"
+)
+
+func writeDataDir(t *testing.T, movies []model.Movie, basic []model.BasicMovie) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, v := range map[string]interface{}{
+		datafile.PopularMovies: movies,
+		datafile.BasicMovies:   basic,
+		datafile.MoviesLite:    optimize.Lite(movies),
+	} {
+		if err := datafile.Write(filepath.Join(dir, name), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestCheckToolOutput(t *testing.T) {
+	movies := []model.Movie{
+		{
+			ID: 1, Title: "Alien", Overview: "In space.", ReleaseDate: "1979-05-25", VoteAverage: 8.2, VoteCount: 15000,
+			Genres:   []model.Genre{{ID: 27, Name: "Horror"}},
+			Director: model.MovieDirector{ID: 578, Name: "Ridley Scott"},
+			Actors:   []model.MovieActor{{ID: 10205, Name: "Sigourney Weaver"}},
+		},
+		// No cast or genres at all must still produce arrays, not null.
+		{ID: 2, Title: "Flow", Overview: "A cat.", ReleaseDate: "2024-08-29", Genres: []model.Genre{}, Actors: []model.MovieActor{}},
+	}
+	basic := []model.BasicMovie{{ID: 1, Title: "Alien", ReleaseDate: "1979-05-25"}, {ID: 2, Title: "Flow"}}
+
+	problems, err := Check(writeDataDir(t, movies, basic), SchemaVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("tool output reported problems: %v", problems)
+	}
+}
+
+func TestCheckViolations(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		datafile.PopularMovies: `[{"id": 1, "title": "Alien"}]`,
+		datafile.BasicMovies:   `[{"id": 1, "title": "Alien", "release_date": "25/05/1979", "poster_path": ""}, {"id": 1, "title": "Alien", "release_date": "", "poster_path": "", "extra": true}]`,
+		datafile.MoviesLite:    `[{"id": 1, "d": "Ridley Scott", "g": null, "c": [], "y": "1979"}, {"id": 3, "d": "", "g": [], "c": [], "y": ""}]`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	problems, err := Check(dir, SchemaVersion)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]bool)
+	for _, p := range problems {
+		got[p.String()] = true
+	}
+	for _, want := range []string{
+		`popularMovies.json: /0: missing required property "overview"`,
+		`basicMovies.json: /0/release_date: "25/05/1979" does not match ^(\d{4}-\d{2}-\d{2})?$`,
+		`basicMovies.json: /1: unexpected property "extra"`,
+		`basicMovies.json: duplicate id 1`,
+		`moviesLite.json: /0/g: is null, want array`,
+		`moviesLite.json: id 3 is not in basicMovies.json`,
+	} {
+		if !got[want] {
+			t.Errorf("missing problem %q in %v", want, problems)
+		}
+	}
+}
+
+func TestSchemaRejectsUnsupportedKeywords(t *testing.T) {
+	if _, err := parseSchema([]byte(`{"type": "string", "maxLength": 3}`)); err == nil {
+		t.Error("expected an error for an unsupported keyword")
+	}
+	if _, err := parseSchema([]byte(`{"$ref": "#/$defs/missing"}`)); err == nil {
+		t.Error("expected an error for an unresolvable $ref")
+	}
+}
+
+func TestSchemaTypes(t *testing.T) {
+	s, err := parseSchema([]byte(`{"type": ["integer", "null"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range []interface{}{1.0, nil, 1.5, "1"} {
+		s.Validate(v, func(path, msg string) { got = append(got, msg) })
+	}
+	want := []string{"is number, want integer or null", "is string, want integer or null"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}